@@ -0,0 +1,144 @@
+package fitbit
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+)
+
+// MaxAZMTimeSeriesDateRange is the maximum number of days that can be requested at once by GetAZMTimeSeries.
+const MaxAZMTimeSeriesDateRange = 1095
+
+type (
+	// ActiveZoneMinutes represents Active Zone Minutes and its breakdown by heart rate zones.
+	ActiveZoneMinutes struct {
+		ActiveZoneMinutes        int64 `json:"activeZoneMinutes"`
+		FatBurnActiveZoneMinutes int64 `json:"fatBurnActiveZoneMinutes"`
+		CardioActiveZoneMinutes  int64 `json:"cardioActiveZoneMinutes"`
+		PeakActiveZoneMinutes    int64 `json:"peakActiveZoneMinutes"`
+	}
+
+	rawAZMDay struct {
+		DateTime string            `json:"dateTime"`
+		Value    ActiveZoneMinutes `json:"value"`
+	}
+
+	rawAZMTimeSeriesResponse struct {
+		ActivitiesActiveZoneMinutes []AZMDay `json:"activities-active-zone-minutes"`
+	}
+
+	// AZMDay represents Active Zone Minutes of a day.
+	AZMDay struct {
+		Date  *time.Time
+		Value ActiveZoneMinutes
+	}
+
+	rawAZMPoint struct {
+		Minute string            `json:"minute"`
+		Value  ActiveZoneMinutes `json:"value"`
+	}
+
+	rawAZMIntradayResponse struct {
+		ActivitiesActiveZoneMinutesIntraday []struct {
+			DateTime string        `json:"dateTime"`
+			Minutes  []rawAZMPoint `json:"minutes"`
+		} `json:"activities-active-zone-minutes-intraday"`
+	}
+
+	// AZMPoint represents Active Zone Minutes of an interval within a day.
+	AZMPoint struct {
+		Minute *time.Time // in user's local time, located in UTC unless IntradayOption specifies the timezone
+		Value  ActiveZoneMinutes
+	}
+)
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (d *AZMDay) UnmarshalJSON(b []byte) error {
+	var raw rawAZMDay
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return err
+	}
+
+	date, err := parseTime(dateFormat, raw.DateTime)
+	if err != nil {
+		return err
+	}
+
+	d.Date = date
+	d.Value = raw.Value
+	return nil
+}
+
+// GetAZMTimeSeries retrieves the Active Zone Minutes between `start` and `end`.
+//
+// The date range must not exceed MaxAZMTimeSeriesDateRange days.
+//
+// Scope.Activity is required.
+//
+// Web API Reference: https://dev.fitbit.com/build/reference/web-api/active-zone-minutes-timeseries/get-azm-timeseries-by-interval/
+func (c *Client) GetAZMTimeSeries(ctx context.Context, userID string, start, end time.Time, token *Token) ([]AZMDay, *RateLimit, []byte, error) {
+	if err := validateDateRange(start, end, MaxAZMTimeSeriesDateRange); err != nil {
+		return nil, nil, nil, err
+	}
+	endpoint := c.getEndpoint("GetAZMTimeSeries", resolveUserID(userID), start.Format(dateFormat), end.Format(dateFormat))
+	b, rateLimit, err := c.getRequest(ctx, token, endpoint)
+	if err != nil {
+		return nil, nil, b, err
+	}
+	var raw rawAZMTimeSeriesResponse
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return nil, rateLimit, b, err
+	}
+	if raw.ActivitiesActiveZoneMinutes == nil {
+		raw.ActivitiesActiveZoneMinutes = []AZMDay{}
+	}
+	return raw.ActivitiesActiveZoneMinutes, rateLimit, b, nil
+}
+
+// GetAZMIntraday retrieves the intraday Active Zone Minutes on a date.
+//
+// `detail` must be one of Detail1min, Detail5min and Detail15min.
+// Only the intervals having Active Zone Minutes are returned.
+// The timestamps are located in UTC by default, and `opts` can specify the user's timezone.
+//
+// Scope.Activity is required.
+//
+// Access to intraday data requires permission from Fitbit for Server and Client applications.
+// When it is not permitted, *PermissionError is returned.
+//
+// Web API Reference: https://dev.fitbit.com/build/reference/web-api/intraday/get-azm-intraday-by-date/
+func (c *Client) GetAZMIntraday(ctx context.Context, userID string, date time.Time, detail IntradayDetail, token *Token, opts ...IntradayOption) ([]AZMPoint, *RateLimit, []byte, error) {
+	if err := detail.validate(Detail1min, Detail5min, Detail15min); err != nil {
+		return nil, nil, nil, err
+	}
+	if err := rejectUTCTimestamps(opts); err != nil {
+		return nil, nil, nil, err
+	}
+	loc, err := c.intradayLocation(ctx, userID, token, opts)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	endpoint := c.getEndpoint("GetAZMIntraday", resolveUserID(userID), date.Format(dateFormat), detail)
+	b, rateLimit, err := c.getRequest(ctx, token, endpoint)
+	if err != nil {
+		return nil, nil, b, wrapAsPermissionError(err)
+	}
+	var raw rawAZMIntradayResponse
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return nil, rateLimit, b, err
+	}
+	points := []AZMPoint{}
+	for _, day := range raw.ActivitiesActiveZoneMinutesIntraday {
+		for _, m := range day.Minutes {
+			minute, err := parseTimeInLocation("2006-01-02T15:04:05", m.Minute, loc)
+			if err != nil {
+				return nil, rateLimit, b, err
+			}
+			points = append(points, AZMPoint{
+				Minute: minute,
+				Value:  m.Value,
+			})
+		}
+	}
+	return points, rateLimit, b, nil
+}
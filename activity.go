@@ -1,11 +1,95 @@
 package fitbit
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
 	"time"
 )
 
+// ErrNoGPSData is returned by GetActivityTCX when the activity has no GPS data.
+var ErrNoGPSData = errors.New("fitbit: activity has no GPS data")
+
+const (
+	// MaxActivityLogListLimit is the maximum number of activity logs which can be retrieved at once.
+	MaxActivityLogListLimit = 100
+
+	// MaxConcurrentDailyActivitySummaryRequests is the maximum number of requests issued at the same time by GetDailyActivitySummaries.
+	MaxConcurrentDailyActivitySummaryRequests = 4
+
+	// MaxActivityTimeSeriesDateRange is the maximum number of days that can be requested at once by GetActivityTimeSeries.
+	MaxActivityTimeSeriesDateRange = 1095
+
+	activityLogTimeFormat = "2006-01-02T15:04:05.000-07:00"
+)
+
+// GoalPeriod represents the period of activity goals.
+type GoalPeriod string
+
+const (
+	GoalPeriodDaily  GoalPeriod = "daily"
+	GoalPeriodWeekly GoalPeriod = "weekly"
+)
+
+func (p GoalPeriod) validate() error {
+	switch p {
+	case GoalPeriodDaily, GoalPeriodWeekly:
+		return nil
+	}
+	return fmt.Errorf("fitbit: invalid goal period %q", string(p))
+}
+
+// ActivityResource represents a resource of activity time series.
+type ActivityResource string
+
+const (
+	ActivityResourceActivityCalories            ActivityResource = "activityCalories"
+	ActivityResourceCalories                    ActivityResource = "calories"
+	ActivityResourceCaloriesBMR                 ActivityResource = "caloriesBMR"
+	ActivityResourceDistance                    ActivityResource = "distance"
+	ActivityResourceElevation                   ActivityResource = "elevation"
+	ActivityResourceFloors                      ActivityResource = "floors"
+	ActivityResourceMinutesSedentary            ActivityResource = "minutesSedentary"
+	ActivityResourceMinutesLightlyActive        ActivityResource = "minutesLightlyActive"
+	ActivityResourceMinutesFairlyActive         ActivityResource = "minutesFairlyActive"
+	ActivityResourceMinutesVeryActive           ActivityResource = "minutesVeryActive"
+	ActivityResourceSteps                       ActivityResource = "steps"
+	ActivityResourceSwimmingStrokes             ActivityResource = "swimming-strokes"
+	ActivityResourceTrackerActivityCalories     ActivityResource = "tracker/activityCalories"
+	ActivityResourceTrackerCalories             ActivityResource = "tracker/calories"
+	ActivityResourceTrackerDistance             ActivityResource = "tracker/distance"
+	ActivityResourceTrackerElevation            ActivityResource = "tracker/elevation"
+	ActivityResourceTrackerFloors               ActivityResource = "tracker/floors"
+	ActivityResourceTrackerMinutesSedentary     ActivityResource = "tracker/minutesSedentary"
+	ActivityResourceTrackerMinutesLightlyActive ActivityResource = "tracker/minutesLightlyActive"
+	ActivityResourceTrackerMinutesFairlyActive  ActivityResource = "tracker/minutesFairlyActive"
+	ActivityResourceTrackerMinutesVeryActive    ActivityResource = "tracker/minutesVeryActive"
+	ActivityResourceTrackerSteps                ActivityResource = "tracker/steps"
+)
+
+// validateIntraday checks if intraday data is available for the resource.
+func (r ActivityResource) validateIntraday() error {
+	switch r {
+	case ActivityResourceSteps, ActivityResourceCalories, ActivityResourceDistance, ActivityResourceFloors, ActivityResourceElevation:
+		return nil
+	}
+	return fmt.Errorf("fitbit: intraday data is not available for resource %q", string(r))
+}
+
+// responseKey returns the key which holds the time series data in the response.
+func (r ActivityResource) responseKey() string {
+	return "activities-" + strings.ReplaceAll(string(r), "/", "-")
+}
+
 type (
 	rawActivity struct {
 		ActivityID           int64      `json:"activityId"`
@@ -60,6 +144,7 @@ type (
 	Distance struct {
 		Activity string  `json:"activity"`
 		Distance float64 `json:"distance"`
+		Unit     string  `json:"unit"` // Unit is derived from the language setting
 	}
 
 	// HeartRateZone represents a user's heart rate zone.
@@ -93,6 +178,233 @@ type (
 		UseEstimation          bool            `json:"useEstimation"`
 	}
 
+	// ActivityIntraday represents a user's intraday activity data on a date.
+	ActivityIntraday struct {
+		Total           *TimeSeriesPoint
+		Dataset         []IntradayPoint
+		DatasetInterval int64
+		DatasetType     string
+	}
+
+	// ActivityLevel represents minutes spent in an activity level during an activity.
+	ActivityLevel struct {
+		Minutes int64  `json:"minutes"`
+		Name    string `json:"name"`
+	}
+
+	// ActivityLogSource represents the source which recorded an activity log.
+	ActivityLogSource struct {
+		ID              string   `json:"id"`
+		Name            string   `json:"name"`
+		Type            string   `json:"type"`
+		URL             string   `json:"url"`
+		TrackerFeatures []string `json:"trackerFeatures"`
+	}
+
+	rawActivityLog struct {
+		LogID             int64              `json:"logId"`
+		ActivityName      string             `json:"activityName"`
+		ActivityTypeID    int64              `json:"activityTypeId"`
+		LogType           string             `json:"logType"`
+		StartTime         string             `json:"startTime"`
+		OriginalStartTime string             `json:"originalStartTime"`
+		Duration          int64              `json:"duration"`         // in milliseconds
+		ActiveDuration    int64              `json:"activeDuration"`   // in milliseconds
+		OriginalDuration  int64              `json:"originalDuration"` // in milliseconds
+		Calories          float64            `json:"calories"`
+		Steps             int64              `json:"steps"`
+		Distance          float64            `json:"distance"`
+		DistanceUnit      string             `json:"distanceUnit"`
+		ElevationGain     float64            `json:"elevationGain"`
+		AverageHeartRate  int64              `json:"averageHeartRate"`
+		HeartRateZones    []HeartRateZone    `json:"heartRateZones"`
+		ActivityLevel     []ActivityLevel    `json:"activityLevel"`
+		LastModified      *time.Time         `json:"lastModified"`
+		HeartRateLink     string             `json:"heartRateLink"`
+		TCXLink           string             `json:"tcxLink"`
+		Source            *ActivityLogSource `json:"source"`
+	}
+
+	// ActivityLog represents an entry of a user's activity log list.
+	ActivityLog struct {
+		LogID             int64
+		ActivityName      string
+		ActivityTypeID    int64
+		LogType           string
+		StartTime         *time.Time
+		OriginalStartTime *time.Time
+		Duration          time.Duration
+		ActiveDuration    time.Duration
+		OriginalDuration  time.Duration
+		Calories          float64
+		Steps             int64
+		Distance          float64
+		DistanceUnit      string
+		ElevationGain     float64
+		AverageHeartRate  int64
+		HeartRateZones    []HeartRateZone
+		ActivityLevels    []ActivityLevel
+		LastModified      *time.Time
+		HeartRateLink     string
+		TCXLink           string
+		Source            *ActivityLogSource
+	}
+
+	// ActivityLogList represents a page of a user's activity log list.
+	ActivityLogList struct {
+		Activities []ActivityLog `json:"activities"`
+		Pagination *Pagination   `json:"pagination"`
+	}
+
+	// ActivityLogListParams represents parameters to retrieve a user's activity log list.
+	//
+	// Either of BeforeDate or AfterDate is required.
+	// Sort must be SortDescending with BeforeDate, and SortAscending with AfterDate.
+	// It is set accordingly when it is empty.
+	// Limit must be between 1 and MaxActivityLogListLimit.
+	ActivityLogListParams struct {
+		BeforeDate *time.Time
+		AfterDate  *time.Time
+		Sort       SortOrder
+		Limit      int
+		Offset     int // Fitbit only supports 0
+	}
+
+	// ActivityLogRequest represents parameters to create an activity log entry.
+	//
+	// Either ActivityID or ActivityName must be set.
+	// When ActivityName is set, ManualCalories is required.
+	ActivityLogRequest struct {
+		ActivityID     int64
+		ActivityName   string
+		ManualCalories int64     // only used with ActivityName
+		StartTime      time.Time // in user's local time, only the date and the time in minutes are used
+		Duration       time.Duration
+		Distance       *float64
+		DistanceUnit   string // e.g. "Kilometer" or "Mile", the unit of the language setting is used when it is empty
+	}
+
+	// ActivityGoalsUpdate represents parameters to update a user's activity goals.
+	//
+	// Only non-nil fields are sent, and at least one of them must be set.
+	ActivityGoalsUpdate struct {
+		CaloriesOut   *int64
+		ActiveMinutes *int64
+		Distance      *float64 // in the unit of the language setting
+		Floors        *int64
+		Steps         *int64
+	}
+
+	rawGoalsResponse struct {
+		Goals *Goals `json:"goals"`
+	}
+
+	rawLifetimeBestRecord struct {
+		Date  string  `json:"date"`
+		Value float64 `json:"value"`
+	}
+
+	// LifetimeBestRecord represents a best record and the date when it is achieved.
+	LifetimeBestRecord struct {
+		Date  *time.Time
+		Value float64
+	}
+
+	// LifetimeBest represents best records of distance, floors and steps.
+	LifetimeBest struct {
+		Distance *LifetimeBestRecord `json:"distance"`
+		Floors   *LifetimeBestRecord `json:"floors"`
+		Steps    *LifetimeBestRecord `json:"steps"`
+	}
+
+	// LifetimeTotals represents lifetime totals.
+	//
+	// Values which are not available are -1.
+	LifetimeTotals struct {
+		ActiveScore int64   `json:"activeScore"`
+		CaloriesOut int64   `json:"caloriesOut"`
+		Distance    float64 `json:"distance"`
+		Floors      int64   `json:"floors"`
+		Steps       int64   `json:"steps"`
+	}
+
+	// LifetimeStats represents a user's lifetime statistics.
+	//
+	// Total includes manually logged activities, and Tracker includes only tracker data.
+	LifetimeStats struct {
+		Best struct {
+			Total   *LifetimeBest `json:"total"`
+			Tracker *LifetimeBest `json:"tracker"`
+		} `json:"best"`
+		Lifetime struct {
+			Total   *LifetimeTotals `json:"total"`
+			Tracker *LifetimeTotals `json:"tracker"`
+		} `json:"lifetime"`
+	}
+
+	rawActivityDescriptor struct {
+		ActivityID  int64   `json:"activityId"`
+		Name        string  `json:"name"`
+		Description string  `json:"description"`
+		Calories    float64 `json:"calories"`
+		Distance    float64 `json:"distance"`
+		Duration    int64   `json:"duration"` // in milliseconds
+		METs        float64 `json:"mets"`
+	}
+
+	// ActivityDescriptor represents an activity in a user's recent, frequent or favorite activities.
+	//
+	// METs is only set for favorite activities, and the others are only set for recent and frequent activities.
+	ActivityDescriptor struct {
+		ActivityID  int64
+		Name        string
+		Description string
+		Calories    float64
+		Distance    float64
+		Duration    time.Duration
+		METs        float64
+	}
+
+	// ActivityTypeLevel represents an intensity level of an activity type.
+	ActivityTypeLevel struct {
+		ID          int64   `json:"id"`
+		Name        string  `json:"name"`
+		METs        float64 `json:"mets"`
+		MinSpeedMPH float64 `json:"minSpeedMPH"`
+		MaxSpeedMPH float64 `json:"maxSpeedMPH"`
+	}
+
+	// ActivityType represents an activity type in the activity database.
+	ActivityType struct {
+		ID             int64               `json:"id"`
+		Name           string              `json:"name"`
+		AccessLevel    string              `json:"accessLevel"`
+		HasSpeed       bool                `json:"hasSpeed"`
+		METs           float64             `json:"mets"`
+		ActivityLevels []ActivityTypeLevel `json:"activityLevels"`
+	}
+
+	// ActivityCategory represents a category of activity types,
+	// which holds activity types and its sub categories.
+	ActivityCategory struct {
+		ID            int64              `json:"id"`
+		Name          string             `json:"name"`
+		Activities    []ActivityType     `json:"activities"`
+		SubCategories []ActivityCategory `json:"subCategories"`
+	}
+
+	rawActivityTypesResponse struct {
+		Categories []ActivityCategory `json:"categories"`
+	}
+
+	rawActivityTypeResponse struct {
+		Activity *ActivityType `json:"activity"`
+	}
+
+	rawActivityLogResponse struct {
+		ActivityLog *Activity `json:"activityLog"`
+	}
+
 	// DailyActivitySummary represents a summary and list of a user’s
 	// activities and activity log entries.
 	DailyActivitySummary struct {
@@ -120,7 +432,7 @@ func (a *Activity) UnmarshalJSON(b []byte) error {
 	a.DetailsLink = raw.DetailsLink
 	a.Calories = raw.Calories
 	a.StartDateTime = startDateTime
-	a.Duration = time.Duration(raw.Distance * 1000 * 1000) // convert milliseconds to nanoseconds.
+	a.Duration = time.Duration(raw.Duration) * time.Millisecond
 	a.Distance = raw.Distance
 	a.Steps = raw.Steps
 	a.HasActiveZoneMinutes = raw.HasActiveZoneMinutes
@@ -133,6 +445,138 @@ func (a *Activity) UnmarshalJSON(b []byte) error {
 	return nil
 }
 
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (l *ActivityLog) UnmarshalJSON(b []byte) error {
+	var raw rawActivityLog
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return err
+	}
+
+	startTime, err := parseTime(activityLogTimeFormat, raw.StartTime)
+	if err != nil {
+		return err
+	}
+	originalStartTime, err := parseTime(activityLogTimeFormat, raw.OriginalStartTime)
+	if err != nil {
+		return err
+	}
+
+	l.LogID = raw.LogID
+	l.ActivityName = raw.ActivityName
+	l.ActivityTypeID = raw.ActivityTypeID
+	l.LogType = raw.LogType
+	l.StartTime = startTime
+	l.OriginalStartTime = originalStartTime
+	l.Duration = time.Duration(raw.Duration) * time.Millisecond
+	l.ActiveDuration = time.Duration(raw.ActiveDuration) * time.Millisecond
+	l.OriginalDuration = time.Duration(raw.OriginalDuration) * time.Millisecond
+	l.Calories = raw.Calories
+	l.Steps = raw.Steps
+	l.Distance = raw.Distance
+	l.DistanceUnit = raw.DistanceUnit
+	l.ElevationGain = raw.ElevationGain
+	l.AverageHeartRate = raw.AverageHeartRate
+	l.HeartRateZones = raw.HeartRateZones
+	l.ActivityLevels = raw.ActivityLevel
+	l.LastModified = raw.LastModified
+	l.HeartRateLink = raw.HeartRateLink
+	l.TCXLink = raw.TCXLink
+	l.Source = raw.Source
+	return nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (r *LifetimeBestRecord) UnmarshalJSON(b []byte) error {
+	var raw rawLifetimeBestRecord
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return err
+	}
+
+	date, err := parseTime(dateFormat, raw.Date)
+	if err != nil {
+		return err
+	}
+
+	r.Date = date
+	r.Value = raw.Value
+	return nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (d *ActivityDescriptor) UnmarshalJSON(b []byte) error {
+	var raw rawActivityDescriptor
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return err
+	}
+
+	d.ActivityID = raw.ActivityID
+	d.Name = raw.Name
+	d.Description = raw.Description
+	d.Calories = raw.Calories
+	d.Distance = raw.Distance
+	d.Duration = time.Duration(raw.Duration) * time.Millisecond
+	d.METs = raw.METs
+	return nil
+}
+
+func (r *ActivityLogRequest) values() (url.Values, error) {
+	if (r.ActivityID > 0) == (r.ActivityName != "") {
+		return nil, errors.New("fitbit: exactly one of ActivityID and ActivityName must be set")
+	}
+	if r.StartTime.IsZero() {
+		return nil, errors.New("fitbit: StartTime must be set")
+	}
+	if r.Duration <= 0 {
+		return nil, errors.New("fitbit: Duration must be positive")
+	}
+	values := url.Values{}
+	if r.ActivityID > 0 {
+		values.Set("activityId", strconv.FormatInt(r.ActivityID, 10))
+	} else {
+		if r.ManualCalories <= 0 {
+			return nil, errors.New("fitbit: ManualCalories must be positive when ActivityName is set")
+		}
+		values.Set("activityName", r.ActivityName)
+		values.Set("manualCalories", strconv.FormatInt(r.ManualCalories, 10))
+	}
+	values.Set("startTime", r.StartTime.Format("15:04"))
+	values.Set("durationMillis", strconv.FormatInt(int64(r.Duration/time.Millisecond), 10))
+	values.Set("date", r.StartTime.Format(dateFormat))
+	if r.Distance != nil {
+		if *r.Distance < 0 {
+			return nil, errors.New("fitbit: Distance must not be negative")
+		}
+		values.Set("distance", formatFloat(*r.Distance))
+		if r.DistanceUnit != "" {
+			values.Set("distanceUnit", r.DistanceUnit)
+		}
+	}
+	return values, nil
+}
+
+func (u *ActivityGoalsUpdate) values() (url.Values, error) {
+	values := url.Values{}
+	if u.CaloriesOut != nil {
+		values.Set("caloriesOut", strconv.FormatInt(*u.CaloriesOut, 10))
+	}
+	if u.ActiveMinutes != nil {
+		values.Set("activeMinutes", strconv.FormatInt(*u.ActiveMinutes, 10))
+	}
+	if u.Distance != nil {
+		values.Set("distance", formatFloat(*u.Distance))
+	}
+	if u.Floors != nil {
+		values.Set("floors", strconv.FormatInt(*u.Floors, 10))
+	}
+	if u.Steps != nil {
+		values.Set("steps", strconv.FormatInt(*u.Steps, 10))
+	}
+	if len(values) == 0 {
+		return nil, errors.New("fitbit: at least one goal must be set")
+	}
+	return values, nil
+}
+
 // GetDailyActivitySummary retrieves a summary and list of a user’s activities and activity log entries for a given day.
 //
 // Scope.Activity is required.
@@ -141,7 +585,7 @@ func (a *Activity) UnmarshalJSON(b []byte) error {
 //
 // Web API Reference: https://dev.fitbit.com/build/reference/web-api/activity/get-daily-activity-summary/
 func (c *Client) GetDailyActivitySummary(ctx context.Context, userID string, date time.Time, token *Token) (*DailyActivitySummary, *RateLimit, []byte, error) {
-	endpoint := c.getEndpoint("GetDailyActivitySummary", userID, date.Format(dateFormat))
+	endpoint := c.getEndpoint("GetDailyActivitySummary", resolveUserID(userID), date.Format(dateFormat))
 	b, rateLimit, err := c.getRequest(ctx, token, endpoint)
 	if err != nil {
 		return nil, nil, b, err
@@ -150,5 +594,469 @@ func (c *Client) GetDailyActivitySummary(ctx context.Context, userID string, dat
 	if err := json.Unmarshal(b, &dailyActivitySummary); err != nil {
 		return nil, rateLimit, b, err
 	}
+	if summary := dailyActivitySummary.Summary; summary != nil {
+		distanceUnit := c.GetUnit().Distance
+		for i := range summary.Distances {
+			summary.Distances[i].Unit = distanceUnit
+		}
+	}
 	return &dailyActivitySummary, rateLimit, b, nil
 }
+
+// GetDailyActivitySummaries retrieves the daily activity summaries of the dates,
+// issuing requests of GetDailyActivitySummary with at most MaxConcurrentDailyActivitySummaryRequests at the same time.
+//
+// The summaries are keyed by the dates formatted as "2006-01-02", and duplicate dates are requested once.
+// When some of the requests fail, the summaries of the succeeded ones are returned with *BatchError keyed in the same way.
+// Use LastRateLimit to obtain the rate limit.
+//
+// Scope.Activity is required.
+func (c *Client) GetDailyActivitySummaries(ctx context.Context, userID string, dates []time.Time, token *Token) (map[string]*DailyActivitySummary, error) {
+	var (
+		mu        sync.Mutex
+		summaries = make(map[string]*DailyActivitySummary, len(dates))
+		keys      = make([]string, 0, len(dates))
+		dateOf    = make(map[string]time.Time, len(dates))
+	)
+	for _, date := range dates {
+		key := date.Format(dateFormat)
+		if _, ok := dateOf[key]; !ok {
+			keys = append(keys, key)
+			dateOf[key] = date
+		}
+	}
+	errs := runConcurrently(ctx, keys, MaxConcurrentDailyActivitySummaryRequests, func(ctx context.Context, key string) error {
+		summary, _, _, err := c.GetDailyActivitySummary(ctx, userID, dateOf[key], token)
+		if err != nil {
+			return err
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		summaries[key] = summary
+		return nil
+	})
+	if len(errs) > 0 {
+		return summaries, &BatchError{Errors: errs}
+	}
+	return summaries, nil
+}
+
+// GetActivityTimeSeries retrieves the activity data for a given resource over a date range.
+//
+// The date range must not exceed MaxActivityTimeSeriesDateRange days.
+//
+// Scope.Activity is required.
+//
+// Web API Reference: https://dev.fitbit.com/build/reference/web-api/activity-timeseries/get-activity-timeseries-by-date-range/
+func (c *Client) GetActivityTimeSeries(ctx context.Context, userID string, resource ActivityResource, start, end time.Time, token *Token) ([]TimeSeriesPoint, *RateLimit, []byte, error) {
+	if err := validateDateRange(start, end, MaxActivityTimeSeriesDateRange); err != nil {
+		return nil, nil, nil, err
+	}
+	endpoint := c.getEndpoint("GetActivityTimeSeries", resolveUserID(userID), resource, start.Format(dateFormat), end.Format(dateFormat))
+	b, rateLimit, err := c.getRequest(ctx, token, endpoint)
+	if err != nil {
+		return nil, nil, b, err
+	}
+	points, err := parseTimeSeries(b, resource.responseKey())
+	if err != nil {
+		return nil, rateLimit, b, err
+	}
+	return points, rateLimit, b, nil
+}
+
+// GetActivityTimeSeriesByPeriod retrieves the activity data for a given resource over a period ending at a date.
+//
+// Scope.Activity is required.
+//
+// Web API Reference: https://dev.fitbit.com/build/reference/web-api/activity-timeseries/get-activity-timeseries-by-date/
+func (c *Client) GetActivityTimeSeriesByPeriod(ctx context.Context, userID string, resource ActivityResource, end time.Time, period Period, token *Token) ([]TimeSeriesPoint, *RateLimit, []byte, error) {
+	if err := period.validate(); err != nil {
+		return nil, nil, nil, err
+	}
+	endpoint := c.getEndpoint("GetActivityTimeSeriesByPeriod", resolveUserID(userID), resource, end.Format(dateFormat), period)
+	b, rateLimit, err := c.getRequest(ctx, token, endpoint)
+	if err != nil {
+		return nil, nil, b, err
+	}
+	points, err := parseTimeSeries(b, resource.responseKey())
+	if err != nil {
+		return nil, rateLimit, b, err
+	}
+	return points, rateLimit, b, nil
+}
+
+// GetActivityIntraday retrieves the intraday activity data for a given resource on a date.
+//
+// Only ActivityResourceSteps, ActivityResourceCalories, ActivityResourceDistance,
+// ActivityResourceFloors and ActivityResourceElevation are supported,
+// and `detail` must be one of Detail1min, Detail5min and Detail15min.
+//
+// When `window` is not nil, the data is limited within the time window.
+// The timestamps are located in UTC by default, and `opts` can specify the user's timezone or request UTC timestamps.
+//
+// Scope.Activity is required.
+//
+// Access to intraday data requires permission from Fitbit for Server and Client applications.
+// When it is not permitted, *PermissionError is returned.
+//
+// Web API Reference: https://dev.fitbit.com/build/reference/web-api/intraday/get-activity-intraday-by-date/
+func (c *Client) GetActivityIntraday(ctx context.Context, userID string, resource ActivityResource, date time.Time, detail IntradayDetail, window *IntradayTimeWindow, token *Token, opts ...IntradayOption) (*ActivityIntraday, *RateLimit, []byte, error) {
+	if err := resource.validateIntraday(); err != nil {
+		return nil, nil, nil, err
+	}
+	if err := detail.validate(Detail1min, Detail5min, Detail15min); err != nil {
+		return nil, nil, nil, err
+	}
+	var endpoint endpointURL
+	if window != nil {
+		if err := window.validate(); err != nil {
+			return nil, nil, nil, err
+		}
+		endpoint = c.getEndpoint("GetActivityIntradayByTime", resolveUserID(userID), resource, date.Format(dateFormat), detail, window.Start.Format("15:04"), window.End.Format("15:04"))
+	} else {
+		endpoint = c.getEndpoint("GetActivityIntraday", resolveUserID(userID), resource, date.Format(dateFormat), detail)
+	}
+	loc, err := c.intradayLocation(ctx, userID, token, opts)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	endpoint.url += newIntradayOptions(opts).query()
+	b, rateLimit, err := c.getRequest(ctx, token, endpoint)
+	if err != nil {
+		return nil, nil, b, wrapAsPermissionError(err)
+	}
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return nil, rateLimit, b, err
+	}
+	var (
+		totals  []TimeSeriesPoint
+		dataset rawIntradayDataset
+	)
+	if data, ok := raw[resource.responseKey()]; ok {
+		if err := json.Unmarshal(data, &totals); err != nil {
+			return nil, rateLimit, b, err
+		}
+	}
+	if data, ok := raw[resource.responseKey()+"-intraday"]; ok {
+		if err := json.Unmarshal(data, &dataset); err != nil {
+			return nil, rateLimit, b, err
+		}
+	}
+	points, err := dataset.points(date, loc)
+	if err != nil {
+		return nil, rateLimit, b, err
+	}
+	activityIntraday := &ActivityIntraday{
+		Dataset:         points,
+		DatasetInterval: dataset.DatasetInterval,
+		DatasetType:     dataset.DatasetType,
+	}
+	if len(totals) > 0 {
+		activityIntraday.Total = &totals[0]
+	}
+	return activityIntraday, rateLimit, b, nil
+}
+
+// GetActivityLogList retrieves a page of the user's activity log list.
+//
+// Pagination of the result holds the URLs of the next and previous pages,
+// which can be followed by FollowPagination.
+//
+// Scope.Activity is required.
+//
+// Web API Reference: https://dev.fitbit.com/build/reference/web-api/activity/get-activity-log-list/
+func (c *Client) GetActivityLogList(ctx context.Context, userID string, params ActivityLogListParams, token *Token) (*ActivityLogList, *RateLimit, []byte, error) {
+	query, err := listQuery(params.BeforeDate, params.AfterDate, params.Sort, params.Limit, params.Offset, MaxActivityLogListLimit)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	endpoint := c.getEndpoint("GetActivityLogList", resolveUserID(userID), query.Encode())
+	b, rateLimit, err := c.getRequest(ctx, token, endpoint)
+	if err != nil {
+		return nil, nil, b, err
+	}
+	var list ActivityLogList
+	if err := json.Unmarshal(b, &list); err != nil {
+		return nil, rateLimit, b, err
+	}
+	if list.Activities == nil {
+		list.Activities = []ActivityLog{}
+	}
+	return &list, rateLimit, b, nil
+}
+
+// LogActivity creates an activity log entry, and returns the created entry with its assigned log ID.
+//
+// Scope.Activity is required.
+//
+// Web API Reference: https://dev.fitbit.com/build/reference/web-api/activity/create-activity-log/
+func (c *Client) LogActivity(ctx context.Context, userID string, params ActivityLogRequest, token *Token) (*Activity, *RateLimit, []byte, error) {
+	values, err := params.values()
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	endpoint := c.getEndpoint("LogActivity", resolveUserID(userID))
+	b, rateLimit, err := c.postRequest(ctx, token, endpoint, values)
+	if err != nil {
+		return nil, nil, b, err
+	}
+	var resp rawActivityLogResponse
+	if err := json.Unmarshal(b, &resp); err != nil {
+		return nil, rateLimit, b, err
+	}
+	return resp.ActivityLog, rateLimit, b, nil
+}
+
+// DeleteActivityLog deletes an activity log entry.
+//
+// Scope.Activity is required.
+//
+// Web API Reference: https://dev.fitbit.com/build/reference/web-api/activity/delete-activity-log/
+func (c *Client) DeleteActivityLog(ctx context.Context, userID string, logID int64, token *Token) (*RateLimit, error) {
+	if err := validateLogID(logID); err != nil {
+		return nil, err
+	}
+	endpoint := c.getEndpoint("DeleteActivityLog", resolveUserID(userID), logID)
+	_, rateLimit, err := c.deleteRequest(ctx, token, endpoint)
+	if err != nil {
+		return nil, err
+	}
+	return rateLimit, nil
+}
+
+// GetActivityTCX retrieves the TCX (Training Center XML) of an activity log entry, which holds its GPS data.
+//
+// When `includePartialTCX` is true, the TCX is returned even if the activity lacks GPS data.
+// Otherwise, ErrNoGPSData is returned without the TCX when it has no positions.
+//
+// Scope.Activity and Scope.Location are required.
+//
+// Web API Reference: https://dev.fitbit.com/build/reference/web-api/activity/get-activity-tcx/
+func (c *Client) GetActivityTCX(ctx context.Context, userID string, logID int64, includePartialTCX bool, token *Token) ([]byte, *RateLimit, error) {
+	if err := validateLogID(logID); err != nil {
+		return nil, nil, err
+	}
+	endpoint := c.getEndpoint("GetActivityTCX", resolveUserID(userID), logID, includePartialTCX)
+	req, err := http.NewRequest(http.MethodGet, endpoint.url, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	req.Header.Set("Accept", "application/vnd.garmin.tcx+xml")
+	b, rateLimit, err := c.request(ctx, token, endpoint.label, req)
+	if err != nil {
+		return nil, nil, wrapAsRequestError("Get", endpoint.url, err)
+	}
+	if !includePartialTCX {
+		hasPosition, err := hasTCXPosition(b)
+		if err != nil {
+			return nil, rateLimit, err
+		}
+		if !hasPosition {
+			return nil, rateLimit, ErrNoGPSData
+		}
+	}
+	return b, rateLimit, nil
+}
+
+// hasTCXPosition reports whether the TCX has a Position element, regardless of its namespace prefix.
+func hasTCXPosition(b []byte) (bool, error) {
+	decoder := xml.NewDecoder(bytes.NewReader(b))
+	for {
+		t, err := decoder.Token()
+		if err == io.EOF {
+			return false, nil
+		}
+		if err != nil {
+			return false, fmt.Errorf("fitbit: cannot parse TCX: %w", err)
+		}
+		if start, ok := t.(xml.StartElement); ok && start.Name.Local == "Position" {
+			return true, nil
+		}
+	}
+}
+
+// GetActivityGoals retrieves the user's daily or weekly activity goals.
+//
+// Weekly goals do not have CaloriesOut and ActiveMinutes.
+//
+// Scope.Activity is required.
+//
+// Web API Reference: https://dev.fitbit.com/build/reference/web-api/activity/get-activity-goals/
+func (c *Client) GetActivityGoals(ctx context.Context, userID string, period GoalPeriod, token *Token) (*Goals, *RateLimit, []byte, error) {
+	if err := period.validate(); err != nil {
+		return nil, nil, nil, err
+	}
+	endpoint := c.getEndpoint("GetActivityGoals", resolveUserID(userID), period)
+	b, rateLimit, err := c.getRequest(ctx, token, endpoint)
+	if err != nil {
+		return nil, nil, b, err
+	}
+	var resp rawGoalsResponse
+	if err := json.Unmarshal(b, &resp); err != nil {
+		return nil, rateLimit, b, err
+	}
+	return resp.Goals, rateLimit, b, nil
+}
+
+// UpdateActivityGoals updates the user's daily or weekly activity goals.
+//
+// Scope.Activity is required.
+//
+// Web API Reference: https://dev.fitbit.com/build/reference/web-api/activity/create-activity-goals/
+func (c *Client) UpdateActivityGoals(ctx context.Context, userID string, period GoalPeriod, params ActivityGoalsUpdate, token *Token) (*Goals, *RateLimit, []byte, error) {
+	if err := period.validate(); err != nil {
+		return nil, nil, nil, err
+	}
+	values, err := params.values()
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	endpoint := c.getEndpoint("UpdateActivityGoals", resolveUserID(userID), period)
+	b, rateLimit, err := c.postRequest(ctx, token, endpoint, values)
+	if err != nil {
+		return nil, nil, b, err
+	}
+	var resp rawGoalsResponse
+	if err := json.Unmarshal(b, &resp); err != nil {
+		return nil, rateLimit, b, err
+	}
+	return resp.Goals, rateLimit, b, nil
+}
+
+// GetLifetimeStats retrieves the user's lifetime statistics and best records.
+//
+// Scope.Activity is required.
+//
+// Web API Reference: https://dev.fitbit.com/build/reference/web-api/activity/get-lifetime-stats/
+func (c *Client) GetLifetimeStats(ctx context.Context, userID string, token *Token) (*LifetimeStats, *RateLimit, []byte, error) {
+	endpoint := c.getEndpoint("GetLifetimeStats", resolveUserID(userID))
+	b, rateLimit, err := c.getRequest(ctx, token, endpoint)
+	if err != nil {
+		return nil, nil, b, err
+	}
+	var stats LifetimeStats
+	if err := json.Unmarshal(b, &stats); err != nil {
+		return nil, rateLimit, b, err
+	}
+	return &stats, rateLimit, b, nil
+}
+
+// GetRecentActivities retrieves a list of the user's recent activities.
+//
+// Scope.Activity is required.
+//
+// Web API Reference: https://dev.fitbit.com/build/reference/web-api/activity/get-recent-activity-types/
+func (c *Client) GetRecentActivities(ctx context.Context, userID string, token *Token) ([]ActivityDescriptor, *RateLimit, []byte, error) {
+	endpoint := c.getEndpoint("GetRecentActivities", resolveUserID(userID))
+	return c.getActivityDescriptors(ctx, token, endpoint)
+}
+
+// GetFrequentActivities retrieves a list of the user's frequent activities.
+//
+// Scope.Activity is required.
+//
+// Web API Reference: https://dev.fitbit.com/build/reference/web-api/activity/get-frequent-activities/
+func (c *Client) GetFrequentActivities(ctx context.Context, userID string, token *Token) ([]ActivityDescriptor, *RateLimit, []byte, error) {
+	endpoint := c.getEndpoint("GetFrequentActivities", resolveUserID(userID))
+	return c.getActivityDescriptors(ctx, token, endpoint)
+}
+
+// GetFavoriteActivities retrieves a list of the user's favorite activities.
+//
+// Scope.Activity is required.
+//
+// Web API Reference: https://dev.fitbit.com/build/reference/web-api/activity/get-favorite-activities/
+func (c *Client) GetFavoriteActivities(ctx context.Context, userID string, token *Token) ([]ActivityDescriptor, *RateLimit, []byte, error) {
+	endpoint := c.getEndpoint("GetFavoriteActivities", resolveUserID(userID))
+	return c.getActivityDescriptors(ctx, token, endpoint)
+}
+
+func (c *Client) getActivityDescriptors(ctx context.Context, token *Token, endpoint endpointURL) ([]ActivityDescriptor, *RateLimit, []byte, error) {
+	b, rateLimit, err := c.getRequest(ctx, token, endpoint)
+	if err != nil {
+		return nil, nil, b, err
+	}
+	var activities []ActivityDescriptor
+	if err := json.Unmarshal(b, &activities); err != nil {
+		return nil, rateLimit, b, err
+	}
+	if activities == nil {
+		activities = []ActivityDescriptor{}
+	}
+	return activities, rateLimit, b, nil
+}
+
+// AddFavoriteActivity adds the activity to the user's favorite activities.
+//
+// Scope.Activity is required.
+//
+// Web API Reference: https://dev.fitbit.com/build/reference/web-api/activity/create-favorite-activity/
+func (c *Client) AddFavoriteActivity(ctx context.Context, userID string, activityID int64, token *Token) (*RateLimit, error) {
+	if err := validateActivityID(activityID); err != nil {
+		return nil, err
+	}
+	endpoint := c.getEndpoint("AddFavoriteActivity", resolveUserID(userID), activityID)
+	_, rateLimit, err := c.postRequest(ctx, token, endpoint, url.Values{})
+	if err != nil {
+		return nil, err
+	}
+	return rateLimit, nil
+}
+
+// RemoveFavoriteActivity removes the activity from the user's favorite activities.
+//
+// Scope.Activity is required.
+//
+// Web API Reference: https://dev.fitbit.com/build/reference/web-api/activity/delete-favorite-activity/
+func (c *Client) RemoveFavoriteActivity(ctx context.Context, userID string, activityID int64, token *Token) (*RateLimit, error) {
+	if err := validateActivityID(activityID); err != nil {
+		return nil, err
+	}
+	endpoint := c.getEndpoint("RemoveFavoriteActivity", resolveUserID(userID), activityID)
+	_, rateLimit, err := c.deleteRequest(ctx, token, endpoint)
+	if err != nil {
+		return nil, err
+	}
+	return rateLimit, nil
+}
+
+// GetActivityTypes retrieves the tree of all activity categories and activity types in the activity database.
+//
+// The IDs of activity types are used to log activities.
+//
+// Web API Reference: https://dev.fitbit.com/build/reference/web-api/activity/get-all-activity-types/
+func (c *Client) GetActivityTypes(ctx context.Context, token *Token) ([]ActivityCategory, *RateLimit, []byte, error) {
+	endpoint := c.getEndpoint("GetActivityTypes")
+	b, rateLimit, err := c.getRequest(ctx, token, endpoint)
+	if err != nil {
+		return nil, nil, b, err
+	}
+	var resp rawActivityTypesResponse
+	if err := json.Unmarshal(b, &resp); err != nil {
+		return nil, rateLimit, b, err
+	}
+	if resp.Categories == nil {
+		resp.Categories = []ActivityCategory{}
+	}
+	return resp.Categories, rateLimit, b, nil
+}
+
+// GetActivityType retrieves the details of an activity type in the activity database.
+//
+// Web API Reference: https://dev.fitbit.com/build/reference/web-api/activity/get-activity-type/
+func (c *Client) GetActivityType(ctx context.Context, activityID int64, token *Token) (*ActivityType, *RateLimit, []byte, error) {
+	if err := validateActivityID(activityID); err != nil {
+		return nil, nil, nil, err
+	}
+	endpoint := c.getEndpoint("GetActivityType", activityID)
+	b, rateLimit, err := c.getRequest(ctx, token, endpoint)
+	if err != nil {
+		return nil, nil, b, err
+	}
+	var resp rawActivityTypeResponse
+	if err := json.Unmarshal(b, &resp); err != nil {
+		return nil, rateLimit, b, err
+	}
+	return resp.Activity, rateLimit, b, nil
+}
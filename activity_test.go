@@ -0,0 +1,70 @@
+package fitbit
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+)
+
+const (
+	tcxWithPosition = `<?xml version="1.0" encoding="UTF-8"?>
+<TrainingCenterDatabase xmlns="http://www.garmin.com/xmlschemas/TrainingCenterDatabase/v2">
+  <Activities><Activity Sport="Running"><Lap><Track><Trackpoint>
+    <Time>2022-01-02T07:00:00.000+09:00</Time>
+    <Position><LatitudeDegrees>35.681</LatitudeDegrees><LongitudeDegrees>139.767</LongitudeDegrees></Position>
+  </Trackpoint></Track></Lap></Activity></Activities>
+</TrainingCenterDatabase>`
+	tcxWithPrefixedPosition = `<?xml version="1.0" encoding="UTF-8"?>
+<tcx:TrainingCenterDatabase xmlns:tcx="http://www.garmin.com/xmlschemas/TrainingCenterDatabase/v2">
+  <tcx:Activities><tcx:Activity Sport="Running"><tcx:Lap><tcx:Track><tcx:Trackpoint>
+    <tcx:Time>2022-01-02T07:00:00.000+09:00</tcx:Time>
+    <tcx:Position><tcx:LatitudeDegrees>35.681</tcx:LatitudeDegrees><tcx:LongitudeDegrees>139.767</tcx:LongitudeDegrees></tcx:Position>
+  </tcx:Trackpoint></tcx:Track></tcx:Lap></tcx:Activity></tcx:Activities>
+</tcx:TrainingCenterDatabase>`
+	tcxWithoutPosition = `<?xml version="1.0" encoding="UTF-8"?>
+<TrainingCenterDatabase xmlns="http://www.garmin.com/xmlschemas/TrainingCenterDatabase/v2">
+  <Activities><Activity Sport="Running"><Lap><Track><Trackpoint>
+    <Time>2022-01-02T07:00:00.000+09:00</Time>
+    <HeartRateBpm><Value>120</Value></HeartRateBpm>
+  </Trackpoint></Track></Lap></Activity></Activities>
+</TrainingCenterDatabase>`
+)
+
+func TestGetActivityTCX(t *testing.T) {
+	tests := []struct {
+		name              string
+		tcx               string
+		includePartialTCX bool
+		wantErr           error
+	}{
+		{"with positions", tcxWithPosition, false, nil},
+		{"with prefixed positions", tcxWithPrefixedPosition, false, nil},
+		{"without positions", tcxWithoutPosition, false, ErrNoGPSData},
+		{"partial without positions", tcxWithoutPosition, true, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/vnd.garmin.tcx+xml")
+				w.Write([]byte(tt.tcx))
+			}))
+			token := &Token{AccessToken: "access-token", TokenType: "Bearer", Expiry: time.Now().Add(time.Hour)}
+
+			b, _, err := c.GetActivityTCX(context.Background(), "ABC123", 1234, tt.includePartialTCX, token)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("GetActivityTCX() error = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				if b != nil {
+					t.Errorf("GetActivityTCX() = %q, want nil on error", b)
+				}
+				return
+			}
+			if string(b) != tt.tcx {
+				t.Errorf("GetActivityTCX() = %q, want the TCX as is", b)
+			}
+		})
+	}
+}
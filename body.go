@@ -0,0 +1,292 @@
+package fitbit
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+)
+
+// MaxBodyTimeSeriesDateRange is the maximum number of days that can be requested at once by GetBodyTimeSeries.
+const MaxBodyTimeSeriesDateRange = 1095
+
+// BodyResource represents a resource of body time series.
+type BodyResource string
+
+const (
+	BodyResourceBMI    BodyResource = "bmi"
+	BodyResourceFat    BodyResource = "fat"
+	BodyResourceWeight BodyResource = "weight"
+)
+
+func (r BodyResource) validate() error {
+	switch r {
+	case BodyResourceBMI, BodyResourceFat, BodyResourceWeight:
+		return nil
+	}
+	return fmt.Errorf("fitbit: unsupported body resource %q, must be one of bmi, fat and weight", string(r))
+}
+
+type (
+	rawWeightLog struct {
+		BMI    float64 `json:"bmi"`
+		Date   string  `json:"date"`
+		Fat    float64 `json:"fat"`
+		LogID  int64   `json:"logId"`
+		Source string  `json:"source"`
+		Time   string  `json:"time"`
+		Weight float64 `json:"weight"`
+	}
+
+	// WeightLog represents a user's body weight log entry.
+	WeightLog struct {
+		LogID    int64
+		Weight   float64
+		Unit     string // Unit is derived from the language setting
+		BMI      float64
+		Fat      float64
+		DateTime *time.Time
+		Source   string
+	}
+
+	// WeightLogs represents a list of a user's body weight log entries.
+	WeightLogs struct {
+		Logs []WeightLog `json:"weight"`
+	}
+
+	rawLogWeightResponse struct {
+		WeightLog *WeightLog `json:"weightLog"`
+	}
+
+	rawBodyFatLog struct {
+		Date   string  `json:"date"`
+		Fat    float64 `json:"fat"`
+		LogID  int64   `json:"logId"`
+		Source string  `json:"source"`
+		Time   string  `json:"time"`
+	}
+
+	// BodyFatLog represents a user's body fat log entry.
+	BodyFatLog struct {
+		LogID    int64
+		Fat      float64 // in percentage
+		DateTime *time.Time
+		Source   string
+	}
+
+	// BodyFatLogs represents a list of a user's body fat log entries.
+	BodyFatLogs struct {
+		Logs []BodyFatLog `json:"fat"`
+	}
+
+	rawLogBodyFatResponse struct {
+		FatLog *BodyFatLog `json:"fatLog"`
+	}
+)
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (l *WeightLog) UnmarshalJSON(b []byte) error {
+	var raw rawWeightLog
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return err
+	}
+
+	dateTime, err := parseTime("2006-01-0215:04:05", raw.Date+raw.Time)
+	if err != nil {
+		return err
+	}
+
+	l.LogID = raw.LogID
+	l.Weight = raw.Weight
+	l.BMI = raw.BMI
+	l.Fat = raw.Fat
+	l.DateTime = dateTime
+	l.Source = raw.Source
+	return nil
+}
+
+// GetWeightLogs retrieves a list of a user's body weight log entries for a given day.
+//
+// The unit of weight follows the language set by `SetLanguage`.
+//
+// Scope.Weight is required.
+//
+// Web API Reference: https://dev.fitbit.com/build/reference/web-api/body/get-weight-log/
+func (c *Client) GetWeightLogs(ctx context.Context, userID string, date time.Time, token *Token) (*WeightLogs, *RateLimit, []byte, error) {
+	endpoint := c.getEndpoint("GetWeightLogs", resolveUserID(userID), date.Format(dateFormat))
+	b, rateLimit, err := c.getRequest(ctx, token, endpoint)
+	if err != nil {
+		return nil, nil, b, err
+	}
+	var weightLogs WeightLogs
+	if err := json.Unmarshal(b, &weightLogs); err != nil {
+		return nil, rateLimit, b, err
+	}
+	weightUnit := c.GetUnit().Weight
+	for i := range weightLogs.Logs {
+		weightLogs.Logs[i].Unit = weightUnit
+	}
+	return &weightLogs, rateLimit, b, nil
+}
+
+// LogWeight creates a body weight log entry.
+//
+// `weight` must be positive, and is in the unit of the language set by `SetLanguage`.
+// When `t` is nil, the time is set to the end of the day.
+//
+// Scope.Weight is required.
+//
+// Web API Reference: https://dev.fitbit.com/build/reference/web-api/body/create-weight-log/
+func (c *Client) LogWeight(ctx context.Context, userID string, weight float64, date time.Time, t *time.Time, token *Token) (*WeightLog, *RateLimit, []byte, error) {
+	if weight <= 0 {
+		return nil, nil, nil, errors.New("fitbit: weight must be positive")
+	}
+	endpoint := c.getEndpoint("LogWeight", resolveUserID(userID))
+	values := url.Values{}
+	values.Set("weight", formatFloat(weight))
+	values.Set("date", date.Format(dateFormat))
+	if t != nil {
+		values.Set("time", t.Format("15:04:05"))
+	}
+	b, rateLimit, err := c.postRequest(ctx, token, endpoint, values)
+	if err != nil {
+		return nil, nil, b, err
+	}
+	var resp rawLogWeightResponse
+	if err := json.Unmarshal(b, &resp); err != nil {
+		return nil, rateLimit, b, err
+	}
+	if resp.WeightLog != nil {
+		resp.WeightLog.Unit = c.GetUnit().Weight
+	}
+	return resp.WeightLog, rateLimit, b, nil
+}
+
+// DeleteWeightLog deletes a body weight log entry.
+//
+// Scope.Weight is required.
+//
+// Web API Reference: https://dev.fitbit.com/build/reference/web-api/body/delete-weight-log/
+func (c *Client) DeleteWeightLog(ctx context.Context, userID string, logID int64, token *Token) (*RateLimit, error) {
+	if err := validateLogID(logID); err != nil {
+		return nil, err
+	}
+	endpoint := c.getEndpoint("DeleteWeightLog", resolveUserID(userID), logID)
+	_, rateLimit, err := c.deleteRequest(ctx, token, endpoint)
+	if err != nil {
+		return nil, err
+	}
+	return rateLimit, nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (l *BodyFatLog) UnmarshalJSON(b []byte) error {
+	var raw rawBodyFatLog
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return err
+	}
+
+	dateTime, err := parseTime("2006-01-0215:04:05", raw.Date+raw.Time)
+	if err != nil {
+		return err
+	}
+
+	l.LogID = raw.LogID
+	l.Fat = raw.Fat
+	l.DateTime = dateTime
+	l.Source = raw.Source
+	return nil
+}
+
+// GetBodyFatLogs retrieves a list of a user's body fat log entries for a given day.
+//
+// Scope.Weight is required.
+//
+// Web API Reference: https://dev.fitbit.com/build/reference/web-api/body/get-bodyfat-log/
+func (c *Client) GetBodyFatLogs(ctx context.Context, userID string, date time.Time, token *Token) (*BodyFatLogs, *RateLimit, []byte, error) {
+	endpoint := c.getEndpoint("GetBodyFatLogs", resolveUserID(userID), date.Format(dateFormat))
+	b, rateLimit, err := c.getRequest(ctx, token, endpoint)
+	if err != nil {
+		return nil, nil, b, err
+	}
+	var bodyFatLogs BodyFatLogs
+	if err := json.Unmarshal(b, &bodyFatLogs); err != nil {
+		return nil, rateLimit, b, err
+	}
+	return &bodyFatLogs, rateLimit, b, nil
+}
+
+// LogBodyFat creates a body fat log entry.
+//
+// `fat` is the body fat percentage, and must be between 0 and 100.
+// When `t` is nil, the time is set to the end of the day.
+//
+// Scope.Weight is required.
+//
+// Web API Reference: https://dev.fitbit.com/build/reference/web-api/body/create-bodyfat-log/
+func (c *Client) LogBodyFat(ctx context.Context, userID string, fat float64, date time.Time, t *time.Time, token *Token) (*BodyFatLog, *RateLimit, []byte, error) {
+	if fat < 0 || fat > 100 {
+		return nil, nil, nil, errors.New("fitbit: body fat percentage must be between 0 and 100")
+	}
+	endpoint := c.getEndpoint("LogBodyFat", resolveUserID(userID))
+	values := url.Values{}
+	values.Set("fat", formatFloat(fat))
+	values.Set("date", date.Format(dateFormat))
+	if t != nil {
+		values.Set("time", t.Format("15:04:05"))
+	}
+	b, rateLimit, err := c.postRequest(ctx, token, endpoint, values)
+	if err != nil {
+		return nil, nil, b, err
+	}
+	var resp rawLogBodyFatResponse
+	if err := json.Unmarshal(b, &resp); err != nil {
+		return nil, rateLimit, b, err
+	}
+	return resp.FatLog, rateLimit, b, nil
+}
+
+// DeleteBodyFatLog deletes a body fat log entry.
+//
+// Scope.Weight is required.
+//
+// Web API Reference: https://dev.fitbit.com/build/reference/web-api/body/delete-bodyfat-log/
+func (c *Client) DeleteBodyFatLog(ctx context.Context, userID string, logID int64, token *Token) (*RateLimit, error) {
+	if err := validateLogID(logID); err != nil {
+		return nil, err
+	}
+	endpoint := c.getEndpoint("DeleteBodyFatLog", resolveUserID(userID), logID)
+	_, rateLimit, err := c.deleteRequest(ctx, token, endpoint)
+	if err != nil {
+		return nil, err
+	}
+	return rateLimit, nil
+}
+
+// GetBodyTimeSeries retrieves the body data for a given resource over a date range.
+//
+// The date range must not exceed MaxBodyTimeSeriesDateRange days.
+//
+// Scope.Weight is required.
+//
+// Web API Reference: https://dev.fitbit.com/build/reference/web-api/body-timeseries/get-body-timeseries-by-date-range/
+func (c *Client) GetBodyTimeSeries(ctx context.Context, userID string, resource BodyResource, start, end time.Time, token *Token) ([]TimeSeriesPoint, *RateLimit, []byte, error) {
+	if err := resource.validate(); err != nil {
+		return nil, nil, nil, err
+	}
+	if err := validateDateRange(start, end, MaxBodyTimeSeriesDateRange); err != nil {
+		return nil, nil, nil, err
+	}
+	endpoint := c.getEndpoint("GetBodyTimeSeries", resolveUserID(userID), resource, start.Format(dateFormat), end.Format(dateFormat))
+	b, rateLimit, err := c.getRequest(ctx, token, endpoint)
+	if err != nil {
+		return nil, nil, b, err
+	}
+	points, err := parseTimeSeries(b, "body-"+string(resource))
+	if err != nil {
+		return nil, rateLimit, b, err
+	}
+	return points, rateLimit, b, nil
+}
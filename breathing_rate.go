@@ -0,0 +1,127 @@
+package fitbit
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+)
+
+// MaxBreathingRateDateRange is the maximum number of days which can be retrieved by GetBreathingRateByInterval.
+const MaxBreathingRateDateRange = 30
+
+type (
+	rawBreathingRateStage struct {
+		BreathingRate float64 `json:"breathingRate"`
+	}
+
+	rawBreathingRate struct {
+		DateTime string `json:"dateTime"`
+		Value    struct {
+			BreathingRate     *float64               `json:"breathingRate"`
+			DeepSleepSummary  *rawBreathingRateStage `json:"deepSleepSummary"`
+			LightSleepSummary *rawBreathingRateStage `json:"lightSleepSummary"`
+			REMSleepSummary   *rawBreathingRateStage `json:"remSleepSummary"`
+			FullSleepSummary  *rawBreathingRateStage `json:"fullSleepSummary"`
+		} `json:"value"`
+	}
+
+	rawBreathingRateResponse struct {
+		BR []BreathingRate `json:"br"`
+	}
+
+	// BreathingRate represents the average breathing rate during the main sleep of a day.
+	//
+	// Values are in breaths per minute.
+	// The sleep stage breakdown is nil when Fitbit does not return it.
+	BreathingRate struct {
+		Date          *time.Time
+		BreathingRate float64
+		DeepSleep     *float64
+		LightSleep    *float64
+		REMSleep      *float64
+		FullSleep     *float64
+	}
+)
+
+func (s *rawBreathingRateStage) value() *float64 {
+	if s == nil {
+		return nil
+	}
+	v := s.BreathingRate
+	return &v
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (br *BreathingRate) UnmarshalJSON(b []byte) error {
+	var raw rawBreathingRate
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return err
+	}
+
+	date, err := parseTime(dateFormat, raw.DateTime)
+	if err != nil {
+		return err
+	}
+
+	br.Date = date
+	br.DeepSleep = raw.Value.DeepSleepSummary.value()
+	br.LightSleep = raw.Value.LightSleepSummary.value()
+	br.REMSleep = raw.Value.REMSleepSummary.value()
+	br.FullSleep = raw.Value.FullSleepSummary.value()
+	if raw.Value.BreathingRate != nil {
+		br.BreathingRate = *raw.Value.BreathingRate
+	} else if br.FullSleep != nil {
+		br.BreathingRate = *br.FullSleep
+	}
+	return nil
+}
+
+// GetBreathingRate retrieves the breathing rate of the specified date.
+//
+// nil is returned when there is no data for the date.
+//
+// Scope.RespiratoryRate is required.
+//
+// Web API Reference: https://dev.fitbit.com/build/reference/web-api/breathing-rate/get-br-summary-by-date/
+func (c *Client) GetBreathingRate(ctx context.Context, userID string, date time.Time, token *Token) (*BreathingRate, *RateLimit, []byte, error) {
+	endpoint := c.getEndpoint("GetBreathingRate", resolveUserID(userID), date.Format(dateFormat))
+	rates, rateLimit, b, err := c.getBreathingRates(ctx, token, endpoint)
+	if err != nil || len(rates) == 0 {
+		return nil, rateLimit, b, err
+	}
+	return &rates[0], rateLimit, b, nil
+}
+
+// GetBreathingRateByInterval retrieves the breathing rates between `start` and `end`.
+//
+// Fitbit omits days without data, so only the days having data are returned.
+// The date range must not exceed MaxBreathingRateDateRange days.
+//
+// Scope.RespiratoryRate is required.
+//
+// Web API Reference: https://dev.fitbit.com/build/reference/web-api/breathing-rate/get-br-summary-by-interval/
+func (c *Client) GetBreathingRateByInterval(ctx context.Context, userID string, start, end time.Time, token *Token) ([]BreathingRate, *RateLimit, []byte, error) {
+	if err := validateDateRange(start, end, MaxBreathingRateDateRange); err != nil {
+		return nil, nil, nil, err
+	}
+	endpoint := c.getEndpoint("GetBreathingRateByInterval", resolveUserID(userID), start.Format(dateFormat), end.Format(dateFormat))
+	return c.getBreathingRates(ctx, token, endpoint)
+}
+
+func (c *Client) getBreathingRates(ctx context.Context, token *Token, endpoint endpointURL) ([]BreathingRate, *RateLimit, []byte, error) {
+	b, rateLimit, err := c.getRequest(ctx, token, endpoint)
+	if err != nil {
+		return nil, nil, b, err
+	}
+	var raw rawBreathingRateResponse
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return nil, rateLimit, b, err
+	}
+	rates := make([]BreathingRate, 0, len(raw.BR))
+	for _, br := range raw.BR {
+		if br.Date != nil {
+			rates = append(rates, br)
+		}
+	}
+	return rates, rateLimit, b, nil
+}
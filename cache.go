@@ -0,0 +1,74 @@
+package fitbit
+
+import (
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// Cache is the interface to store the responses with ETag, which are reused when Fitbit APIs respond with 304 Not Modified.
+//
+// When it is set by `SetCache`, If-None-Match header is sent with GET requests whose response is cached,
+// and the cached body is returned on 304 Not Modified instead of an error.
+// Implementations must be safe for concurrent use.
+type Cache interface {
+	// Get returns the ETag and the body stored for the key, and whether they are found.
+	Get(key string) (etag string, body []byte, ok bool)
+	// Set stores the ETag and the body for the key, replacing the existing ones.
+	Set(key, etag string, body []byte)
+}
+
+// SetCache sets the cache of the responses with ETag.
+//
+// The responses are cached by URL, Accept-Locale and Accept-Language,
+// and the ones of CurrentUserID are not cached since they differ by token, so specify the user ID to use the cache.
+// Use `SetCacheMetricsFunc` to observe cache hits and misses.
+// It is not set by default.
+func (c *Client) SetCache(cache Cache) {
+	c.cache = cache
+}
+
+// cacheKey returns the key of the request in the cache, or an empty string when the request is not cacheable.
+func (c *Client) cacheKey(req *http.Request) string {
+	if c.cache == nil || req.Method != http.MethodGet || strings.Contains(req.URL.Path, "/user/"+CurrentUserID+"/") {
+		return ""
+	}
+	return req.URL.String() + "\n" + req.Header.Get("Accept-Locale") + "\n" + req.Header.Get("Accept-Language")
+}
+
+type memoryCacheEntry struct {
+	etag string
+	body []byte
+}
+
+// MemoryCache is a Cache which keeps responses in memory without eviction.
+//
+// The zero value is ready to use.
+type MemoryCache struct {
+	mu      sync.RWMutex
+	entries map[string]memoryCacheEntry
+}
+
+// Get implements the Cache interface.
+func (mc *MemoryCache) Get(key string) (string, []byte, bool) {
+	mc.mu.RLock()
+	defer mc.mu.RUnlock()
+	entry, ok := mc.entries[key]
+	if !ok {
+		return "", nil, false
+	}
+	return entry.etag, append([]byte(nil), entry.body...), true
+}
+
+// Set implements the Cache interface.
+func (mc *MemoryCache) Set(key, etag string, body []byte) {
+	mc.mu.Lock()
+	defer mc.mu.Unlock()
+	if mc.entries == nil {
+		mc.entries = make(map[string]memoryCacheEntry)
+	}
+	mc.entries[key] = memoryCacheEntry{
+		etag: etag,
+		body: append([]byte(nil), body...),
+	}
+}
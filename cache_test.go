@@ -0,0 +1,57 @@
+package fitbit
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestCacheMetricsFunc(t *testing.T) {
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("ETag", `"v1"`)
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"user":{}}`))
+	}))
+	c.SetCache(&MemoryCache{})
+	var hits []bool
+	c.SetCacheMetricsFunc(func(endpoint string, hit bool) {
+		if want := "/1/user/{user-id}/profile.json"; endpoint != want {
+			t.Errorf("endpoint = %q, want %q", endpoint, want)
+		}
+		hits = append(hits, hit)
+	})
+	var statusCodes []int
+	c.SetMetricsFunc(func(endpoint string, statusCode int, dur time.Duration) {
+		statusCodes = append(statusCodes, statusCode)
+	})
+	token := &Token{AccessToken: "access-token", TokenType: "Bearer", Expiry: time.Now().Add(time.Hour)}
+
+	for i := 0; i < 2; i++ {
+		b, _, err := c.getRequest(context.Background(), token, c.getEndpoint("GetProfile", "ABC123"))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if string(b) != `{"user":{}}` {
+			t.Errorf("body = %q, want the cached one", b)
+		}
+	}
+	if len(hits) != 2 || hits[0] || !hits[1] {
+		t.Errorf("hits = %v, want [false true]", hits)
+	}
+	if len(statusCodes) != 2 || statusCodes[0] != http.StatusOK || statusCodes[1] != http.StatusNotModified {
+		t.Errorf("status codes = %v, want [200 304]", statusCodes)
+	}
+
+	// The requests of CurrentUserID are not cacheable, so they are not reported.
+	if _, _, err := c.getRequest(context.Background(), token, c.getEndpoint("GetProfile", CurrentUserID)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(hits) != 2 {
+		t.Errorf("len(hits) = %d, want 2", len(hits))
+	}
+}
@@ -0,0 +1,164 @@
+package fitbit
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// MaxCardioFitnessScoreDateRange is the maximum number of days which can be retrieved by
+// GetCardioFitnessScoreByInterval.
+const MaxCardioFitnessScoreDateRange = 30
+
+type (
+	// VO2Max represents the VO2 Max value, in mL/kg/min.
+	//
+	// Fitbit returns either a single value, or a range like "37-41"
+	// when the value is estimated without GPS data.
+	// Value is set for the former, Low and High are set for the latter.
+	VO2Max struct {
+		Value *float64
+		Low   *float64
+		High  *float64
+	}
+
+	rawCardioFitnessScore struct {
+		DateTime string `json:"dateTime"`
+		Value    struct {
+			VO2Max VO2Max `json:"vo2Max"`
+		} `json:"value"`
+	}
+
+	rawCardioFitnessScoreResponse struct {
+		CardioScore []CardioFitnessScore `json:"cardioScore"`
+	}
+
+	// CardioFitnessScore represents the cardio fitness score of a day.
+	CardioFitnessScore struct {
+		Date   *time.Time
+		VO2Max VO2Max
+	}
+)
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (v *VO2Max) UnmarshalJSON(b []byte) error {
+	var raw interface{}
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return err
+	}
+
+	var s string
+	switch value := raw.(type) {
+	case nil:
+		return nil
+	case float64:
+		v.Value = &value
+		return nil
+	case string:
+		s = strings.TrimSpace(value)
+	default:
+		return fmt.Errorf("fitbit: unexpected vo2Max value %s", string(b))
+	}
+
+	if parts := strings.SplitN(s, "-", 2); len(parts) == 2 {
+		low, err := strconv.ParseFloat(strings.TrimSpace(parts[0]), 64)
+		if err != nil {
+			return err
+		}
+		high, err := strconv.ParseFloat(strings.TrimSpace(parts[1]), 64)
+		if err != nil {
+			return err
+		}
+		v.Low, v.High = &low, &high
+		return nil
+	}
+	value, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return err
+	}
+	v.Value = &value
+	return nil
+}
+
+// Representative returns a representative value of VO2 Max, which is Value itself,
+// or the midpoint of Low and High for a range.
+// It returns false when there is no value.
+func (v VO2Max) Representative() (float64, bool) {
+	switch {
+	case v.Value != nil:
+		return *v.Value, true
+	case v.Low != nil && v.High != nil:
+		return (*v.Low + *v.High) / 2, true
+	}
+	return 0, false
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (cfs *CardioFitnessScore) UnmarshalJSON(b []byte) error {
+	var raw rawCardioFitnessScore
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return err
+	}
+
+	date, err := parseTime(dateFormat, raw.DateTime)
+	if err != nil {
+		return err
+	}
+
+	cfs.Date = date
+	cfs.VO2Max = raw.Value.VO2Max
+	return nil
+}
+
+// GetCardioFitnessScore retrieves the cardio fitness score of the specified date.
+//
+// nil is returned when there is no data for the date.
+//
+// Scope.CardioFitness is required.
+//
+// Web API Reference: https://dev.fitbit.com/build/reference/web-api/cardio-fitness-score/get-vo2max-summary-by-date/
+func (c *Client) GetCardioFitnessScore(ctx context.Context, userID string, date time.Time, token *Token) (*CardioFitnessScore, *RateLimit, []byte, error) {
+	endpoint := c.getEndpoint("GetCardioFitnessScore", resolveUserID(userID), date.Format(dateFormat))
+	scores, rateLimit, b, err := c.getCardioFitnessScores(ctx, token, endpoint)
+	if err != nil || len(scores) == 0 {
+		return nil, rateLimit, b, err
+	}
+	return &scores[0], rateLimit, b, nil
+}
+
+// GetCardioFitnessScoreByInterval retrieves the cardio fitness scores between `start` and `end`.
+//
+// Fitbit omits days without data, so only the days having data are returned.
+// The date range must not exceed MaxCardioFitnessScoreDateRange days.
+//
+// Scope.CardioFitness is required.
+//
+// Web API Reference: https://dev.fitbit.com/build/reference/web-api/cardio-fitness-score/get-vo2max-summary-by-interval/
+func (c *Client) GetCardioFitnessScoreByInterval(ctx context.Context, userID string, start, end time.Time, token *Token) ([]CardioFitnessScore, *RateLimit, []byte, error) {
+	if err := validateDateRange(start, end, MaxCardioFitnessScoreDateRange); err != nil {
+		return nil, nil, nil, err
+	}
+	endpoint := c.getEndpoint("GetCardioFitnessScoreByInterval", resolveUserID(userID), start.Format(dateFormat), end.Format(dateFormat))
+	return c.getCardioFitnessScores(ctx, token, endpoint)
+}
+
+func (c *Client) getCardioFitnessScores(ctx context.Context, token *Token, endpoint endpointURL) ([]CardioFitnessScore, *RateLimit, []byte, error) {
+	b, rateLimit, err := c.getRequest(ctx, token, endpoint)
+	if err != nil {
+		return nil, nil, b, err
+	}
+	var raw rawCardioFitnessScoreResponse
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return nil, rateLimit, b, err
+	}
+	scores := make([]CardioFitnessScore, 0, len(raw.CardioScore))
+	for _, score := range raw.CardioScore {
+		if score.Date != nil {
+			scores = append(scores, score)
+		}
+	}
+	return scores, rateLimit, b, nil
+}
@@ -0,0 +1,250 @@
+package fitbit
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+)
+
+type dateRange struct {
+	start time.Time
+	end   time.Time
+}
+
+// splitDateRange splits the range from `start` to `end` into consecutive ranges of at most `maxDays` days.
+func splitDateRange(start, end time.Time, maxDays int) []dateRange {
+	var ranges []dateRange
+	for chunkStart := start; daysBetween(chunkStart, end) >= 0; {
+		chunkEnd := chunkStart.AddDate(0, 0, maxDays-1)
+		if daysBetween(chunkEnd, end) < 0 {
+			chunkEnd = end
+		}
+		ranges = append(ranges, dateRange{start: chunkStart, end: chunkEnd})
+		chunkStart = chunkEnd.AddDate(0, 0, 1)
+	}
+	return ranges
+}
+
+// fetchChunked calls `fetch` sequentially for each range split from `start` to `end`,
+// and returns the rate limit of the last response.
+func fetchChunked(start, end time.Time, maxDays int, fetch func(start, end time.Time) (*RateLimit, error)) (*RateLimit, error) {
+	if err := validateDateRange(start, end, 0); err != nil {
+		return nil, err
+	}
+	var lastRateLimit *RateLimit
+	for _, r := range splitDateRange(start, end, maxDays) {
+		rateLimit, err := fetch(r.start, r.end)
+		if rateLimit != nil {
+			lastRateLimit = rateLimit
+		}
+		if err != nil {
+			return lastRateLimit, err
+		}
+	}
+	return lastRateLimit, nil
+}
+
+// fetchDaily calls `fetch` for each date from `start` to `end` with at most `concurrency` calls running at the same time,
+// and returns *BatchError keyed by the dates formatted as "2006-01-02" when some of the calls fail.
+// The range must not exceed `maxDays` days.
+func fetchDaily(ctx context.Context, start, end time.Time, maxDays, concurrency int, fetch func(ctx context.Context, key string, date time.Time) error) error {
+	if err := validateDateRange(start, end, maxDays); err != nil {
+		return err
+	}
+	ranges := splitDateRange(start, end, 1)
+	keys := make([]string, len(ranges))
+	dateOf := make(map[string]time.Time, len(ranges))
+	for i, r := range ranges {
+		keys[i] = r.start.Format(dateFormat)
+		dateOf[keys[i]] = r.start
+	}
+	errs := runConcurrently(ctx, keys, concurrency, func(ctx context.Context, key string) error {
+		return fetch(ctx, key, dateOf[key])
+	})
+	if len(errs) > 0 {
+		return &BatchError{Errors: errs}
+	}
+	return nil
+}
+
+// dedupeAndSort returns the indices of `n` elements without the ones of duplicate keys, keeping the first ones,
+// in the order of the time of each element. The elements of the same time keep their order.
+func dedupeAndSort(n int, key func(i int) string, at func(i int) time.Time) []int {
+	seen := make(map[string]bool, n)
+	indices := make([]int, 0, n)
+	for i := 0; i < n; i++ {
+		k := key(i)
+		if seen[k] {
+			continue
+		}
+		seen[k] = true
+		indices = append(indices, i)
+	}
+	sort.SliceStable(indices, func(i, j int) bool {
+		return at(indices[i]).Before(at(indices[j]))
+	})
+	return indices
+}
+
+// mergeTimeSeriesPoints removes the points on duplicate dates, keeping the first ones, and sorts them by date.
+func mergeTimeSeriesPoints(points []TimeSeriesPoint) []TimeSeriesPoint {
+	indices := dedupeAndSort(len(points), func(i int) string {
+		return timeValue(points[i].Date).Format(dateFormat)
+	}, func(i int) time.Time {
+		return timeValue(points[i].Date)
+	})
+	merged := make([]TimeSeriesPoint, len(indices))
+	for i, idx := range indices {
+		merged[i] = points[idx]
+	}
+	return merged
+}
+
+// GetActivityTimeSeriesChunked retrieves the activity data for a given resource over a date range
+// which may exceed MaxActivityTimeSeriesDateRange days.
+//
+// The range is split into the ranges allowed by Fitbit APIs, which are requested sequentially,
+// and the results are concatenated in chronological order without duplicate dates.
+// Each range consumes the rate limit, so enable `SetRetry` to wait for it to be reset when it is exceeded.
+// On error, the data retrieved so far is returned with the error.
+//
+// Scope.Activity is required.
+func (c *Client) GetActivityTimeSeriesChunked(ctx context.Context, userID string, resource ActivityResource, start, end time.Time, token *Token) ([]TimeSeriesPoint, *RateLimit, error) {
+	points := []TimeSeriesPoint{}
+	rateLimit, err := fetchChunked(start, end, MaxActivityTimeSeriesDateRange, func(start, end time.Time) (*RateLimit, error) {
+		chunk, rateLimit, _, err := c.GetActivityTimeSeries(ctx, userID, resource, start, end, token)
+		points = append(points, chunk...)
+		return rateLimit, err
+	})
+	return mergeTimeSeriesPoints(points), rateLimit, err
+}
+
+// GetBodyTimeSeriesChunked retrieves the body data for a given resource over a date range
+// which may exceed MaxBodyTimeSeriesDateRange days.
+//
+// It behaves in the same way as GetActivityTimeSeriesChunked.
+//
+// Scope.Weight is required.
+func (c *Client) GetBodyTimeSeriesChunked(ctx context.Context, userID string, resource BodyResource, start, end time.Time, token *Token) ([]TimeSeriesPoint, *RateLimit, error) {
+	points := []TimeSeriesPoint{}
+	rateLimit, err := fetchChunked(start, end, MaxBodyTimeSeriesDateRange, func(start, end time.Time) (*RateLimit, error) {
+		chunk, rateLimit, _, err := c.GetBodyTimeSeries(ctx, userID, resource, start, end, token)
+		points = append(points, chunk...)
+		return rateLimit, err
+	})
+	return mergeTimeSeriesPoints(points), rateLimit, err
+}
+
+// GetHeartRateTimeSeriesChunked retrieves the heart rate data over a date range
+// which may exceed MaxHeartRateTimeSeriesDateRange days.
+//
+// It behaves in the same way as GetActivityTimeSeriesChunked.
+//
+// Scope.Heartrate is required.
+func (c *Client) GetHeartRateTimeSeriesChunked(ctx context.Context, userID string, start, end time.Time, token *Token) ([]HeartRateDay, *RateLimit, error) {
+	days := []HeartRateDay{}
+	rateLimit, err := fetchChunked(start, end, MaxHeartRateTimeSeriesDateRange, func(start, end time.Time) (*RateLimit, error) {
+		chunk, rateLimit, _, err := c.GetHeartRateTimeSeries(ctx, userID, start, end, token)
+		days = append(days, chunk...)
+		return rateLimit, err
+	})
+
+	indices := dedupeAndSort(len(days), func(i int) string {
+		return timeValue(days[i].Date).Format(dateFormat)
+	}, func(i int) time.Time {
+		return timeValue(days[i].Date)
+	})
+	merged := make([]HeartRateDay, len(indices))
+	for i, idx := range indices {
+		merged[i] = days[idx]
+	}
+	return merged, rateLimit, err
+}
+
+// GetSleepLogByDateRangeChunked retrieves a list of a user's sleep log entries for a date range
+// which may exceed MaxSleepLogDateRange days.
+//
+// It behaves in the same way as GetActivityTimeSeriesChunked,
+// except that the entries are deduplicated by LogID and sorted by StartTime.
+// The summaries of the ranges are not returned since they cannot be combined.
+//
+// Scope.Sleep is required.
+func (c *Client) GetSleepLogByDateRangeChunked(ctx context.Context, userID string, start, end time.Time, token *Token) ([]SleepRecord, *RateLimit, error) {
+	records := []SleepRecord{}
+	rateLimit, err := fetchChunked(start, end, MaxSleepLogDateRange, func(start, end time.Time) (*RateLimit, error) {
+		sleepLog, rateLimit, _, err := c.GetSleepLogByDateRange(ctx, userID, start, end, token)
+		if sleepLog != nil {
+			records = append(records, sleepLog.Sleep...)
+		}
+		return rateLimit, err
+	})
+
+	indices := dedupeAndSort(len(records), func(i int) string {
+		return strconv.FormatInt(records[i].LogID, 10)
+	}, func(i int) time.Time {
+		return timeValue(records[i].StartTime)
+	})
+	merged := make([]SleepRecord, len(indices))
+	for i, idx := range indices {
+		merged[i] = records[idx]
+	}
+	return merged, rateLimit, err
+}
+
+// GetFoodLogsByDateRange retrieves the food logs of each day from `start` to `end`,
+// issuing requests of GetFoodLogs with at most MaxConcurrentNutritionRequests at the same time.
+//
+// The date range must not exceed MaxNutritionDateRange days.
+// The food logs are keyed by the dates formatted as "2006-01-02".
+// When some of the requests fail, the food logs of the succeeded ones are returned with *BatchError keyed in the same way.
+// Each day consumes the rate limit, so enable `SetRetry` to wait for it to be reset when it is exceeded.
+// Use LastRateLimit to obtain the rate limit.
+//
+// Scope.Nutrition is required.
+func (c *Client) GetFoodLogsByDateRange(ctx context.Context, userID string, start, end time.Time, token *Token) (map[string]*FoodLogs, error) {
+	var (
+		mu       sync.Mutex
+		foodLogs = make(map[string]*FoodLogs)
+	)
+	err := fetchDaily(ctx, start, end, MaxNutritionDateRange, MaxConcurrentNutritionRequests, func(ctx context.Context, key string, date time.Time) error {
+		logs, _, _, err := c.GetFoodLogs(ctx, userID, date, token)
+		if err != nil {
+			return err
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		foodLogs[key] = logs
+		return nil
+	})
+	return foodLogs, err
+}
+
+// GetWaterLogsByDateRange retrieves the water logs of each day from `start` to `end`,
+// issuing requests of GetWater with at most MaxConcurrentNutritionRequests at the same time.
+//
+// The date range must not exceed MaxNutritionDateRange days.
+// The water logs are keyed by the dates formatted as "2006-01-02".
+// When some of the requests fail, the water logs of the succeeded ones are returned with *BatchError keyed in the same way.
+// Each day consumes the rate limit, so enable `SetRetry` to wait for it to be reset when it is exceeded.
+// Use LastRateLimit to obtain the rate limit.
+//
+// Scope.Nutrition is required.
+func (c *Client) GetWaterLogsByDateRange(ctx context.Context, userID string, start, end time.Time, token *Token) (map[string]*Water, error) {
+	var (
+		mu    sync.Mutex
+		water = make(map[string]*Water)
+	)
+	err := fetchDaily(ctx, start, end, MaxNutritionDateRange, MaxConcurrentNutritionRequests, func(ctx context.Context, key string, date time.Time) error {
+		w, _, _, err := c.GetWater(ctx, userID, date, token)
+		if err != nil {
+			return err
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		water[key] = w
+		return nil
+	})
+	return water, err
+}
@@ -0,0 +1,29 @@
+package fitbit
+
+import (
+	"testing"
+	"time"
+)
+
+func TestMergeTimeSeriesPoints(t *testing.T) {
+	date := func(day int) *time.Time {
+		d := time.Date(2022, 1, day, 0, 0, 0, 0, time.UTC)
+		return &d
+	}
+	points := []TimeSeriesPoint{
+		{Date: date(3), Value: "3"},
+		{Date: date(1), Value: "1"},
+		{Date: date(2), Value: "2"},
+		{Date: date(1), Value: "duplicate"},
+	}
+	merged := mergeTimeSeriesPoints(points)
+	want := []string{"1", "2", "3"}
+	if len(merged) != len(want) {
+		t.Fatalf("len(merged) = %d, want %d", len(merged), len(want))
+	}
+	for i, p := range merged {
+		if p.Value != want[i] {
+			t.Errorf("merged[%d].Value = %q, want %q", i, p.Value, want[i])
+		}
+	}
+}
@@ -1,13 +1,20 @@
 package fitbit
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"io/ioutil"
+	"net"
 	"net/http"
 	"net/url"
+	"strconv"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/anyappinc/fitbit/logger"
 	"golang.org/x/oauth2"
@@ -16,12 +23,36 @@ import (
 
 // Client is a client to interact with Fitbit APIs.
 type Client struct {
-	oauth2Config    *oauth2.Config
-	locale          Locale
-	language        Locale
-	applicationType ApplicationType
-	updateTokenFunc func(*Token, *Token) error
-	debugMode       bool
+	oauth2Config     *oauth2.Config
+	baseURL          string
+	locale           Locale
+	language         Locale
+	foodLocale       Locale
+	applicationType  ApplicationType
+	updateTokenFunc  func(*Token, *Token) error
+	tokenStore       TokenStore
+	cache            Cache
+	expiryDelta      time.Duration
+	httpClient       *http.Client
+	maxRetries       int
+	maxRetryWait     time.Duration
+	defaultTimeout   time.Duration
+	idempotentDel    bool
+	debugMode        bool
+	userAgent        string
+	logFunc          func(*http.Request, *http.Response, error, time.Duration)
+	tracer           Tracer
+	metricsFunc      func(string, int, time.Duration)
+	cacheMetricsFunc func(string, bool)
+
+	rateLimitMu   sync.Mutex
+	lastRateLimit *RateLimit
+
+	refreshMu    sync.Mutex
+	refreshCalls map[string]*refreshCall
+
+	profileLocationsMu sync.Mutex
+	profileLocations   map[string]*time.Location
 }
 
 // NewClient initializes Fitbit API Client.
@@ -50,21 +81,42 @@ func NewClient(clientID, clientSecret string, applicationType ApplicationType, s
 			Scopes:       scope.convert(),
 			Endpoint:     fitbitEndpoint,
 		},
+		baseURL:         apiBaseURL,
 		locale:          LocaleUnitedStates, // default setting. See https://dev.fitbit.com/build/reference/web-api/developer-guide/application-design/#Language
 		applicationType: applicationType,
+		expiryDelta:     DefaultExpiryDelta,
+		userAgent:       DefaultUserAgent,
 	}
 }
 
 // SetLocale sets locale.
-// This value is used to set Accept-Locale header.
+// This value is used to set Accept-Locale header, which selects the food database among others.
+// It is LocaleUnitedStates by default.
+//
+// When it is set to empty, the header is not sent.
 //
 // See more details https://dev.fitbit.com/build/reference/web-api/developer-guide/application-design/#Language
 func (c *Client) SetLocale(locale Locale) {
 	c.locale = locale
 }
 
+// SetFoodLocale sets the locale used for Accept-Locale header only on the requests about foods,
+// like SearchFoods, CreateFood and the food logs, which selects the food database.
+// It enables to search the food database of a locale while the others follow `SetLocale`.
+//
+// The locale must be one of AllLocales, and when it is set to empty, the one set by `SetLocale` is used.
+func (c *Client) SetFoodLocale(locale Locale) error {
+	if locale != "" && !locale.IsValid() {
+		return fmt.Errorf("fitbit: unsupported food locale %q", string(locale))
+	}
+	c.foodLocale = locale
+	return nil
+}
+
 // SetLanguage sets language.
-// This value is used to set Accept-Language header.
+// This value is used to set Accept-Language header, which determines the units in API responses.
+//
+// When it is not set, the header is not sent and Fitbit uses the metric units.
 //
 // See more details https://dev.fitbit.com/build/reference/web-api/developer-guide/application-design/#Unit-Systems
 func (c *Client) SetLanguage(locale Locale) {
@@ -83,84 +135,570 @@ func (c *Client) GetUnit() *Unit {
 }
 
 // SetUpdateTokenFunc sets the function to be invoked when a token is updated.
+//
+// When the function returns an error, the request fails with *TokenPersistError,
+// while failures of Fitbit APIs on token refresh are reported as *APIError.
 func (c *Client) SetUpdateTokenFunc(f func(*Token, *Token) error) {
 	c.updateTokenFunc = f
 }
 
-// EnableDebugMode enables debug mode
+// SetExpiryDelta sets the duration before the expiry at which an access token is considered expired.
+//
+// The token is refreshed proactively when it expires within `d`,
+// so that a request does not fail with an access token which expires on the way.
+// It is DefaultExpiryDelta by default.
+func (c *Client) SetExpiryDelta(d time.Duration) {
+	c.expiryDelta = d
+}
+
+// SetHTTPClient sets the HTTP client used to send requests.
+//
+// The client is used for all requests, including token exchange and token refresh.
+// When it is not set, http.DefaultClient is used.
+func (c *Client) SetHTTPClient(hc *http.Client) {
+	c.httpClient = hc
+}
+
+// SetUserAgent sets User-Agent header sent with all requests, including token exchange and token refresh.
+// The header set to a request given to the client returned by `HTTPClient` is respected.
+//
+// It is DefaultUserAgent by default. When it is set to empty, the default one of net/http package is sent.
+func (c *Client) SetUserAgent(userAgent string) {
+	c.userAgent = userAgent
+}
+
+// SetLogger sets the function to be invoked after each HTTP request, including token exchange, token refresh and retries,
+// with the request, the response or the error, and the elapsed time.
+//
+// Authorization header of the request is redacted, and neither the request body nor the response body is given
+// so that tokens and secrets are not leaked. The function must not modify the request and the response.
+// It is not set by default.
+func (c *Client) SetLogger(f func(req *http.Request, resp *http.Response, err error, dur time.Duration)) {
+	c.logFunc = f
+}
+
+// SetAPIBaseURL sets the base URL of Fitbit APIs, like "http://127.0.0.1:8080".
+// It is mainly intended for testing against a mock server such as httptest.Server.
+//
+// The URL must be absolute with http or https scheme, and a trailing slash is ignored.
+// It is "https://api.fitbit.com" by default.
+func (c *Client) SetAPIBaseURL(rawURL string) error {
+	if err := validateBaseURL(rawURL); err != nil {
+		return err
+	}
+	c.baseURL = strings.TrimSuffix(rawURL, "/")
+	return nil
+}
+
+// SetAPIHost sets the host of Fitbit APIs, like "api.fitbit.com", to be requested over https.
+// It is useful when Fitbit serves some accounts from an alternate host.
+//
+// The host must be a valid hostname optionally followed by a port, without scheme or path.
+// It is equivalent to SetAPIBaseURL with "https://" prepended.
+func (c *Client) SetAPIHost(host string) error {
+	if err := validateHost(host); err != nil {
+		return err
+	}
+	c.baseURL = "https://" + host
+	return nil
+}
+
+// SetOAuth2Endpoint sets the authorization URL and the token URL.
+// It is mainly intended for testing against a mock server such as httptest.Server.
+//
+// Both URLs must be absolute with http or https scheme.
+// They are the ones of Fitbit by default.
+func (c *Client) SetOAuth2Endpoint(authURL, tokenURL string) error {
+	if err := validateBaseURL(authURL); err != nil {
+		return err
+	}
+	if err := validateBaseURL(tokenURL); err != nil {
+		return err
+	}
+	c.oauth2Config.Endpoint.AuthURL = authURL
+	c.oauth2Config.Endpoint.TokenURL = tokenURL
+	return nil
+}
+
+func validateBaseURL(rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return fmt.Errorf("fitbit: invalid URL %q: %w", rawURL, err)
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("fitbit: URL must be absolute with http or https scheme: %q", rawURL)
+	}
+	return nil
+}
+
+func validateHost(host string) error {
+	u, err := url.Parse("https://" + host)
+	if err != nil || u.Host != host || u.User != nil {
+		return fmt.Errorf("fitbit: invalid host %q", host)
+	}
+	hostname := u.Hostname()
+	if hostname == "" || len(hostname) > 253 {
+		return fmt.Errorf("fitbit: invalid host %q", host)
+	}
+	if port := u.Port(); port != "" {
+		if n, err := strconv.Atoi(port); err != nil || n < 1 || n > 65535 {
+			return fmt.Errorf("fitbit: invalid port in host %q", host)
+		}
+	} else if strings.HasSuffix(host, ":") {
+		return fmt.Errorf("fitbit: invalid port in host %q", host)
+	}
+	if net.ParseIP(hostname) != nil {
+		return nil
+	}
+	for _, label := range strings.Split(hostname, ".") {
+		if label == "" || len(label) > 63 || label[0] == '-' || label[len(label)-1] == '-' {
+			return fmt.Errorf("fitbit: invalid host %q", host)
+		}
+		for _, r := range label {
+			if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '-') {
+				return fmt.Errorf("fitbit: invalid host %q", host)
+			}
+		}
+	}
+	return nil
+}
+
+// SetRetry enables retrying a request up to `maxRetries` times
+// when the rate limit is exceeded and Fitbit APIs respond with 429 Too Many Requests.
+//
+// Before each retry, it waits for the duration specified by Retry-After header, or Fitbit-Rate-Limit-Reset header without it.
+// When the duration exceeds `maxWait`, the request is not retried and the 429 Too Many Requests response is returned,
+// since retrying earlier would just consume the rate limit. The wait is interrupted when the context is done.
+// The RateLimit returned from API calls reflects the last response.
+//
+// Retrying is disabled when `maxRetries` is 0, which is the default.
+//
+// See more details https://dev.fitbit.com/build/reference/web-api/developer-guide/application-design/#Rate-Limits
+func (c *Client) SetRetry(maxRetries int, maxWait time.Duration) {
+	c.maxRetries = maxRetries
+	c.maxRetryWait = maxWait
+}
+
+// SetDefaultTimeout sets the timeout applied to each request, including token refresh and retries,
+// when the given context has no deadline.
+//
+// A deadline of the context is always respected and never extended.
+// No timeout is applied when `d` is 0, which is the default.
+func (c *Client) SetDefaultTimeout(d time.Duration) {
+	c.defaultTimeout = d
+}
+
+// SetIdempotentDeletes makes the methods deleting a log or a resource, like DeleteWaterLog, DeleteSleepLog and DeleteSubscription,
+// succeed when Fitbit APIs respond with 404 Not Found, so that a retried deletion does not fail.
+//
+// Note that a deletion with a wrong ID also succeeds when it is enabled.
+// It is disabled by default.
+func (c *Client) SetIdempotentDeletes(idempotent bool) {
+	c.idempotentDel = idempotent
+}
+
+// LastRateLimit returns the rate limit obtained from the last response.
+//
+// It returns nil if no response with the rate limit headers has been received yet.
+// It is safe to call from multiple goroutines.
+func (c *Client) LastRateLimit() *RateLimit {
+	c.rateLimitMu.Lock()
+	defer c.rateLimitMu.Unlock()
+	if c.lastRateLimit == nil {
+		return nil
+	}
+	rateLimit := *c.lastRateLimit
+	return &rateLimit
+}
+
+func (c *Client) setLastRateLimit(rateLimit *RateLimit) {
+	if rateLimit == nil {
+		return
+	}
+	copied := *rateLimit
+	c.rateLimitMu.Lock()
+	defer c.rateLimitMu.Unlock()
+	c.lastRateLimit = &copied
+}
+
+// EnableDebugMode enables debug mode, which is intended for development.
+//
+// In debug mode,
+//   - GenerateAuthCodeURL forces the consent screen to be shown unless WithPrompt is given,
+//     so that the scopes can be granted again.
+//   - The error message of *APIError includes the status code and the raw response body.
+//
+// It is disabled by default.
 func (c *Client) EnableDebugMode() {
 	c.debugMode = true
 }
 
-// DisableDebugMode disables debug mode
+// DisableDebugMode disables debug mode.
 func (c *Client) DisableDebugMode() {
 	c.debugMode = false
 }
 
-func (c *Client) getRequest(ctx context.Context, token *Token, url string) ([]byte, *RateLimit, error) {
-	req, err := http.NewRequest(http.MethodGet, url, nil)
+// SetDebugMode enables debug mode when `debug` is true, and disables it otherwise.
+// See EnableDebugMode for what debug mode changes.
+func (c *Client) SetDebugMode(debug bool) {
+	c.debugMode = debug
+}
+
+// parseError parses an error response, making *APIError verbose in debug mode.
+func (c *Client) parseError(r *http.Response, b []byte) error {
+	err := parseError(r, b)
+	if apiErr := (*APIError)(nil); errors.As(err, &apiErr) {
+		apiErr.verbose = c.debugMode
+	}
+	return err
+}
+
+func (c *Client) getRequest(ctx context.Context, token *Token, endpoint endpointURL) ([]byte, *RateLimit, error) {
+	req, err := http.NewRequest(http.MethodGet, endpoint.url, nil)
 	if err != nil {
 		return nil, nil, err
 	}
-	b, rateLimit, err := c.request(ctx, token, req)
-	return b, rateLimit, wrapAsRequestError("Get", url, err)
+	b, rateLimit, err := c.request(ctx, token, endpoint.label, req)
+	return b, rateLimit, wrapAsRequestError("Get", endpoint.url, err)
 }
 
-func (c *Client) postRequest(ctx context.Context, token *Token, url string, data url.Values) ([]byte, *RateLimit, error) {
-	req, err := http.NewRequest(http.MethodPost, url, strings.NewReader(data.Encode()))
+func (c *Client) postRequest(ctx context.Context, token *Token, endpoint endpointURL, data url.Values) ([]byte, *RateLimit, error) {
+	req, err := http.NewRequest(http.MethodPost, endpoint.url, strings.NewReader(data.Encode()))
 	if err != nil {
 		return nil, nil, err
 	}
 	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
-	b, rateLimit, err := c.request(ctx, token, req)
-	return b, rateLimit, wrapAsRequestError("Post", url, err)
+	b, rateLimit, err := c.request(ctx, token, endpoint.label, req)
+	return b, rateLimit, wrapAsRequestError("Post", endpoint.url, err)
 }
 
-func (c *Client) getEndpoint(label string, params ...interface{}) string {
-	return fmt.Sprintf(apiBaseURL+apiEndpoints[label], params...)
+func (c *Client) postJSONRequest(ctx context.Context, token *Token, endpoint endpointURL, data interface{}) ([]byte, *RateLimit, error) {
+	body, err := json.Marshal(data)
+	if err != nil {
+		return nil, nil, err
+	}
+	req, err := http.NewRequest(http.MethodPost, endpoint.url, bytes.NewReader(body))
+	if err != nil {
+		return nil, nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	b, rateLimit, err := c.request(ctx, token, endpoint.label, req)
+	return b, rateLimit, wrapAsRequestError("Post", endpoint.url, err)
+}
+
+func (c *Client) deleteRequest(ctx context.Context, token *Token, endpoint endpointURL) ([]byte, *RateLimit, error) {
+	req, err := http.NewRequest(http.MethodDelete, endpoint.url, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	b, rateLimit, err := c.request(ctx, token, endpoint.label, req)
+	if apiErr := (*APIError)(nil); c.idempotentDel && errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound {
+		return nil, rateLimit, nil
+	}
+	return b, rateLimit, wrapAsRequestError("Delete", endpoint.url, err)
+}
+
+// Do sends a request to the endpoint at `path` and decodes the JSON response into `out`,
+// handling authentication, locale settings, the rate limit and retries in the same way as the other methods.
+//
+// `path` is relative to the API base URL and must start with a slash followed by the API version,
+// like "/1/user/-/profile.json". The query may be included in `path`.
+// An empty `method` means GET. When `body` is not nil, it is sent as application/x-www-form-urlencoded.
+// When `out` is nil, the response body is discarded.
+//
+// It is useful to call endpoints that this package does not support yet.
+// A non-2xx response is returned as *APIError wrapped by *RequestError.
+func (c *Client) Do(ctx context.Context, token *Token, method, path string, body io.Reader, out interface{}) error {
+	if method == "" {
+		method = http.MethodGet
+	}
+	if !strings.HasPrefix(path, "/") {
+		return fmt.Errorf("fitbit: path must start with a slash: %q", path)
+	}
+	url := c.baseURL + path
+	req, err := http.NewRequest(method, url, body)
+	if err != nil {
+		return err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	}
+	b, _, err := c.request(ctx, token, "", req)
+	if err != nil {
+		return wrapAsRequestError(method[:1]+strings.ToLower(method[1:]), url, err)
+	}
+	if out == nil || len(b) == 0 {
+		return nil
+	}
+	return json.Unmarshal(b, out)
+}
+
+// GetRaw retrieves the endpoint at `path` and returns the JSON response as is.
+//
+// `path` is the same as the one of Do. It is useful to access fields which this package does not model yet.
+// The typed methods also return the raw response body alongside the decoded value.
+func (c *Client) GetRaw(ctx context.Context, token *Token, path string) (json.RawMessage, error) {
+	var raw json.RawMessage
+	if err := c.Do(ctx, token, http.MethodGet, path, nil, &raw); err != nil {
+		return nil, err
+	}
+	return raw, nil
+}
+
+func resolveUserID(userID string) string {
+	if userID == "" {
+		return CurrentUserID
+	}
+	return userID
+}
+
+// endpointURL is the URL of an endpoint with the label of it in apiEndpoints,
+// which identifies the endpoint in metrics. The label is empty for URLs not built by getEndpoint.
+type endpointURL struct {
+	label string
+	url   string
+}
+
+func (c *Client) getEndpoint(label string, params ...interface{}) endpointURL {
+	return endpointURL{
+		label: label,
+		url:   c.baseURL + fmt.Sprintf(apiEndpoints[label], params...),
+	}
+}
+
+// contextWithHTTPClient returns a copy of ctx which holds the configured HTTP client,
+// so that oauth2 package and the token refresher use it.
+//
+// The transport of the client is wrapped to set User-Agent header and to invoke the logger.
+func (c *Client) contextWithHTTPClient(ctx context.Context) context.Context {
+	if c.httpClient == nil && c.userAgent == "" && c.logFunc == nil {
+		return ctx
+	}
+	var httpClient http.Client
+	if c.httpClient != nil {
+		httpClient = *c.httpClient
+	}
+	transport := httpClient.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	if c.logFunc != nil {
+		transport = &loggingTransport{
+			base:    transport,
+			logFunc: c.logFunc,
+		}
+	}
+	if c.userAgent != "" {
+		transport = &userAgentTransport{
+			base:      transport,
+			userAgent: c.userAgent,
+		}
+	}
+	httpClient.Transport = transport
+	return context.WithValue(ctx, oauth2.HTTPClient, &httpClient)
+}
+
+// loggingTransport is a http.RoundTripper that invokes the logger with the redacted request and response.
+type loggingTransport struct {
+	base    http.RoundTripper
+	logFunc func(*http.Request, *http.Response, error, time.Duration)
+}
+
+// RoundTrip implements the http.RoundTripper interface.
+func (t *loggingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	start := time.Now()
+	resp, err := t.base.RoundTrip(req)
+	dur := time.Since(start)
+
+	loggedReq := req.Clone(req.Context())
+	loggedReq.Body = nil
+	loggedReq.GetBody = nil
+	if loggedReq.Header.Get("Authorization") != "" {
+		loggedReq.Header.Set("Authorization", "REDACTED")
+	}
+	var loggedResp *http.Response
+	if resp != nil {
+		copied := *resp
+		copied.Body = http.NoBody
+		copied.Request = loggedReq
+		loggedResp = &copied
+	}
+	t.logFunc(loggedReq, loggedResp, err, dur)
+	return resp, err
+}
+
+// userAgentTransport is a http.RoundTripper that sets User-Agent header unless a request has it.
+type userAgentTransport struct {
+	base      http.RoundTripper
+	userAgent string
+}
+
+// RoundTrip implements the http.RoundTripper interface.
+func (t *userAgentTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Header.Get("User-Agent") == "" {
+		req = req.Clone(req.Context())
+		req.Header.Set("User-Agent", t.userAgent)
+	}
+	return t.base.RoundTrip(req)
 }
 
 func (c *Client) newHTTPClient(ctx context.Context, token *Token) *http.Client {
-	if c.updateTokenFunc != nil {
-		return oauth2.NewClient(ctx, c.tokenSource(ctx, token))
+	ctx = c.contextWithHTTPClient(ctx)
+	httpClient := oauth2.NewClient(ctx, c.TokenSource(ctx, token))
+	if c.httpClient != nil {
+		// oauth2 package only takes over the transport, so copy the rest of settings.
+		httpClient.CheckRedirect = c.httpClient.CheckRedirect
+		httpClient.Jar = c.httpClient.Jar
+		httpClient.Timeout = c.httpClient.Timeout
+	}
+	if c.maxRetries > 0 {
+		httpClient.Transport = &retryTransport{
+			base:       httpClient.Transport,
+			maxRetries: c.maxRetries,
+			maxWait:    c.maxRetryWait,
+		}
 	}
-	return c.oauth2Config.Client(ctx, token.asOAuth2Token())
+	return httpClient
 }
 
-func (c *Client) tokenSource(ctx context.Context, token *Token) oauth2.TokenSource {
-	t, tkr := token.asOAuth2Token(), &tokenRefresher{
+// TokenSource returns oauth2.TokenSource which returns the token while it is valid,
+// and refreshes it otherwise, in the same way as the client does.
+//
+// The function set by `SetUpdateTokenFunc` is invoked on refresh.
+// The returned source is safe for concurrent use.
+func (c *Client) TokenSource(ctx context.Context, token *Token) oauth2.TokenSource {
+	ctx = c.contextWithHTTPClient(ctx)
+	return &tokenRefresher{
 		ctx:       ctx,
 		client:    c,
 		lastToken: token,
 	}
-	return oauth2.ReuseTokenSource(t, tkr)
 }
 
-func (c *Client) request(ctx context.Context, token *Token, req *http.Request) ([]byte, *RateLimit, error) {
+// HTTPClient returns *http.Client which authenticates requests with the token,
+// and refreshes it when needed.
+//
+// Accept-Locale and Accept-Language headers are set according to the client settings
+// unless a request has them, the rate limit is recorded for LastRateLimit,
+// and requests are retried as configured by SetRetry.
+// It is useful to call endpoints that this package does not support yet.
+func (c *Client) HTTPClient(ctx context.Context, token *Token) *http.Client {
+	httpClient := c.newHTTPClient(ctx, token)
+	httpClient.Transport = &clientTransport{
+		base:   httpClient.Transport,
+		client: c,
+	}
+	return httpClient
+}
+
+// clientTransport is a http.RoundTripper that applies the client settings to requests.
+type clientTransport struct {
+	base   http.RoundTripper
+	client *Client
+}
+
+// RoundTrip implements the http.RoundTripper interface.
+func (t *clientTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	if locale := t.client.locale.asString(); locale != "" && req.Header.Get("Accept-Locale") == "" {
+		req.Header.Set("Accept-Locale", locale)
+	}
+	if language := t.client.language.asString(); language != "" && req.Header.Get("Accept-Language") == "" {
+		req.Header.Set("Accept-Language", language)
+	}
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	t.client.setLastRateLimit(extractRateLimit(&resp.Header))
+	return resp, nil
+}
+
+func (c *Client) request(ctx context.Context, token *Token, label string, req *http.Request) ([]byte, *RateLimit, error) {
+	if _, ok := ctx.Deadline(); !ok && c.defaultTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.defaultTimeout)
+		defer cancel()
+	}
+	var span Span
+	if c.tracer != nil {
+		ctx, span = c.tracer.Start(ctx, "fitbit "+req.Method+" "+templateEndpoint(label))
+		span.SetAttribute(AttributeHTTPMethod, req.Method)
+		span.SetAttribute(AttributeURLPath, req.URL.Path)
+	}
+	start := time.Now()
+	b, rateLimit, statusCode, err := c.send(ctx, token, label, req)
+	if c.metricsFunc != nil {
+		c.metricsFunc(templateEndpoint(label), statusCode, time.Since(start))
+	}
+	if span != nil {
+		if statusCode != 0 {
+			span.SetAttribute(AttributeHTTPStatusCode, statusCode)
+		}
+		if rateLimit != nil {
+			span.SetAttribute(AttributeRateLimitRemaining, rateLimit.Remaining)
+		}
+		span.End(err)
+	}
+	return b, rateLimit, err
+}
+
+// send sends the request and returns the response body, the rate limit and the status code.
+//
+// `label` is the label of the endpoint, which is used to report cache hits and misses.
+func (c *Client) send(ctx context.Context, token *Token, label string, req *http.Request) ([]byte, *RateLimit, int, error) {
 	httpClient := c.newHTTPClient(ctx, token)
-	req.Header.Set("Accept-Locale", c.locale.asString())
-	req.Header.Set("Accept-Language", c.language.asString())
+	req = req.WithContext(ctx)
+	acceptLocale := c.locale
+	if l, ok := ctx.Value(acceptLocaleKey{}).(Locale); ok {
+		acceptLocale = l
+	}
+	if locale := acceptLocale.asString(); locale != "" {
+		req.Header.Set("Accept-Locale", locale)
+	}
+	if language := c.language.asString(); language != "" {
+		req.Header.Set("Accept-Language", language)
+	}
+	var cached []byte
+	cacheKey := c.cacheKey(req)
+	if cacheKey != "" {
+		if etag, body, ok := c.cache.Get(cacheKey); ok {
+			req.Header.Set("If-None-Match", etag)
+			cached = body
+		}
+	}
 	resp, err := httpClient.Do(req)
 	if err != nil {
 		if uErr := (*url.Error)(nil); errors.As(err, &uErr) {
 			if rErr := (*oauth2.RetrieveError)(nil); errors.As(uErr, &rErr) {
-				if e := parseError(rErr.Response, rErr.Body); e != nil {
-					return nil, nil, fmt.Errorf("fitbit(oauth2): cannot fetch token: %w", e)
+				if e := c.parseError(rErr.Response, rErr.Body); e != nil {
+					return nil, nil, 0, fmt.Errorf("fitbit(oauth2): cannot fetch token: %w", e)
 				}
-				return nil, nil, errors.New("fitbit(oauth2): cannot fetch token")
+				return nil, nil, 0, errors.New("fitbit(oauth2): cannot fetch token")
 			}
-			return nil, nil, uErr.Unwrap()
+			return nil, nil, 0, uErr.Unwrap()
 		}
-		return nil, nil, err
+		return nil, nil, 0, err
 	}
 	defer resp.Body.Close()
 	b, err := ioutil.ReadAll(resp.Body)
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, resp.StatusCode, err
 	}
 	rateLimit := extractRateLimit(&resp.Header)
+	c.setLastRateLimit(rateLimit)
+	hit := resp.StatusCode == http.StatusNotModified && cached != nil
+	if cacheKey != "" && c.cacheMetricsFunc != nil {
+		c.cacheMetricsFunc(templateEndpoint(label), hit)
+	}
+	if hit {
+		return cached, rateLimit, resp.StatusCode, nil
+	}
 	if resp.StatusCode < 200 || resp.StatusCode > 299 {
-		return b, rateLimit, parseError(resp, b)
+		return b, rateLimit, resp.StatusCode, c.parseError(resp, b)
+	}
+	if etag := resp.Header.Get("ETag"); cacheKey != "" && etag != "" {
+		c.cache.Set(cacheKey, etag, b)
 	}
-	return b, rateLimit, nil
+	return b, rateLimit, resp.StatusCode, nil
 }
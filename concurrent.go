@@ -0,0 +1,64 @@
+package fitbit
+
+import (
+	"context"
+	"sync"
+)
+
+// FetchConcurrently calls `fn` for each user of `tokens`, which maps user IDs to their tokens,
+// with at most `concurrency` calls running at the same time.
+//
+// It returns a map from user IDs to the errors returned by `fn`, which is empty when all calls succeed.
+// When `ctx` is done, the calls not started yet are skipped and ctx.Err() is reported for them.
+// The client is shared by all calls, so the token refresh and the rate limit are handled in the same way as sequential calls.
+// `concurrency` less than 1 is treated as 1.
+func (c *Client) FetchConcurrently(ctx context.Context, tokens map[string]*Token, concurrency int, fn func(ctx context.Context, userID string, token *Token) error) map[string]error {
+	userIDs := make([]string, 0, len(tokens))
+	for userID := range tokens {
+		userIDs = append(userIDs, userID)
+	}
+	return runConcurrently(ctx, userIDs, concurrency, func(ctx context.Context, userID string) error {
+		return fn(ctx, userID, tokens[userID])
+	})
+}
+
+// runConcurrently calls `fn` for each key with at most `concurrency` calls running at the same time,
+// and returns the errors keyed by the keys.
+func runConcurrently(ctx context.Context, keys []string, concurrency int, fn func(ctx context.Context, key string) error) map[string]error {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	var (
+		mu    sync.Mutex
+		errs  = make(map[string]error)
+		wg    sync.WaitGroup
+		queue = make(chan string)
+	)
+	setError := func(key string, err error) {
+		mu.Lock()
+		defer mu.Unlock()
+		errs[key] = err
+	}
+	for i := 0; i < concurrency && i < len(keys); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for key := range queue {
+				if err := ctx.Err(); err != nil {
+					setError(key, err)
+					continue
+				}
+				if err := fn(ctx, key); err != nil {
+					setError(key, err)
+				}
+			}
+		}()
+	}
+	for _, key := range keys {
+		queue <- key
+	}
+	close(queue)
+	wg.Wait()
+	return errs
+}
@@ -2,19 +2,107 @@ package fitbit
 
 const (
 	apiBaseURL               = "https://api.fitbit.com"
-	dateFormat               = "2006-01-02"                 // dateFormat is a format string to represent date
-	CodeChallengeMethod      = "S256"                       // CodeChallengeMethod is the method used to hash the code challenge
-	NumberLetters            = "0123456789"                 // NumberLetters is a set of characters represent numbers
-	UppercaseAlphabetLetters = "ABCDEFGHIJKLMNOPQRSTUVWXYZ" // UppercaseAlphabetLetters is a set of upper case alphabetic characters
-	LowercaseAlphabetLetters = "abcdefghijklmnopqrstuvwxyz" // LowercaseAlphabetLetters is a set of lower case alphabetic characters
+	Version                  = "0.1.0"                          // Version is the version of this package
+	DefaultUserAgent         = "anyappinc-fitbit-go/" + Version // DefaultUserAgent is the User-Agent header sent by default
+	dateFormat               = "2006-01-02"                     // dateFormat is a format string to represent date
+	localDateTimeFormat      = "2006-01-02T15:04:05.000"        // localDateTimeFormat is a format string to represent date and time in user's local time
+	CurrentUserID            = "-"                              // CurrentUserID represents the user who owns the token. It is used when an empty user ID is given
+	CodeChallengeMethod      = "S256"                           // CodeChallengeMethod is the method used to hash the code challenge
+	NumberLetters            = "0123456789"                     // NumberLetters is a set of characters represent numbers
+	UppercaseAlphabetLetters = "ABCDEFGHIJKLMNOPQRSTUVWXYZ"     // UppercaseAlphabetLetters is a set of upper case alphabetic characters
+	LowercaseAlphabetLetters = "abcdefghijklmnopqrstuvwxyz"     // LowercaseAlphabetLetters is a set of lower case alphabetic characters
 )
 
 var (
 	apiEndpoints = map[string]string{
-		"GetDailyActivitySummary": "/1/user/%s/activities/date/%s.json",
-		"IntrospectToken":         "/1.1/oauth2/introspect",
-		"RevokeToken":             "/oauth2/revoke",
-		"GetWater":                "/1/user/%s/foods/log/water/date/%s.json",
-		"GetProfile":              "/1/user/%s/profile.json",
+		"GetDailyActivitySummary":         "/1/user/%s/activities/date/%s.json",
+		"GetActivityTimeSeries":           "/1/user/%s/activities/%s/date/%s/%s.json",
+		"GetActivityTimeSeriesByPeriod":   "/1/user/%s/activities/%s/date/%s/%s.json",
+		"GetActivityIntraday":             "/1/user/%s/activities/%s/date/%s/1d/%s.json",
+		"GetActivityIntradayByTime":       "/1/user/%s/activities/%s/date/%s/1d/%s/time/%s/%s.json",
+		"GetAZMTimeSeries":                "/1/user/%s/activities/active-zone-minutes/date/%s/%s.json",
+		"GetAZMIntraday":                  "/1/user/%s/activities/active-zone-minutes/date/%s/1d/%s.json",
+		"GetActivityLogList":              "/1/user/%s/activities/list.json?%s",
+		"LogActivity":                     "/1/user/%s/activities.json",
+		"DeleteActivityLog":               "/1/user/%s/activities/%d.json",
+		"GetActivityTCX":                  "/1/user/%s/activities/%d.tcx?includePartialTCX=%t",
+		"GetActivityGoals":                "/1/user/%s/activities/goals/%s.json",
+		"UpdateActivityGoals":             "/1/user/%s/activities/goals/%s.json",
+		"GetLifetimeStats":                "/1/user/%s/activities.json",
+		"GetRecentActivities":             "/1/user/%s/activities/recent.json",
+		"GetFrequentActivities":           "/1/user/%s/activities/frequent.json",
+		"GetFavoriteActivities":           "/1/user/%s/activities/favorite.json",
+		"AddFavoriteActivity":             "/1/user/%s/activities/favorite/%d.json",
+		"RemoveFavoriteActivity":          "/1/user/%s/activities/favorite/%d.json",
+		"GetActivityTypes":                "/1/activities.json",
+		"GetActivityType":                 "/1/activities/%d.json",
+		"GetHeartRateTimeSeries":          "/1/user/%s/activities/heart/date/%s/%s.json",
+		"GetHeartRateIntraday":            "/1/user/%s/activities/heart/date/%s/1d/%s.json",
+		"GetHeartRateIntradayByInterval":  "/1/user/%s/activities/heart/date/%s/1d/%s/time/%s/%s.json",
+		"GetHRVSummary":                   "/1/user/%s/hrv/date/%s.json",
+		"GetHRVIntraday":                  "/1/user/%s/hrv/date/%s/all.json",
+		"GetBreathingRate":                "/1/user/%s/br/date/%s.json",
+		"GetBreathingRateByInterval":      "/1/user/%s/br/date/%s/%s.json",
+		"GetCardioFitnessScore":           "/1/user/%s/cardioscore/date/%s.json",
+		"GetCardioFitnessScoreByInterval": "/1/user/%s/cardioscore/date/%s/%s.json",
+		"IntrospectToken":                 "/1.1/oauth2/introspect",
+		"RevokeToken":                     "/oauth2/revoke",
+		"GetWater":                        "/1/user/%s/foods/log/water/date/%s.json",
+		"LogWater":                        "/1/user/%s/foods/log/water.json",
+		"UpdateWaterLog":                  "/1/user/%s/foods/log/water/%d.json",
+		"DeleteWaterLog":                  "/1/user/%s/foods/log/water/%d.json",
+		"GetWaterGoal":                    "/1/user/%s/foods/log/water/goal.json",
+		"UpdateWaterGoal":                 "/1/user/%s/foods/log/water/goal.json",
+		"GetFoodLogs":                     "/1/user/%s/foods/log/date/%s.json",
+		"LogFood":                         "/1/user/%s/foods/log.json",
+		"UpdateFoodLog":                   "/1/user/%s/foods/log/%d.json",
+		"DeleteFoodLog":                   "/1/user/%s/foods/log/%d.json",
+		"GetFoodGoals":                    "/1/user/%s/foods/log/goal.json",
+		"UpdateFoodGoals":                 "/1/user/%s/foods/log/goal.json",
+		"SearchFoods":                     "/1/foods/search.json?query=%s",
+		"GetFoodUnits":                    "/1/foods/units.json",
+		"GetFavoriteFoods":                "/1/user/%s/foods/log/favorite.json",
+		"GetFrequentFoods":                "/1/user/%s/foods/log/frequent.json",
+		"GetRecentFoods":                  "/1/user/%s/foods/log/recent.json",
+		"AddFavoriteFood":                 "/1/user/%s/foods/log/favorite/%d.json",
+		"DeleteFavoriteFood":              "/1/user/%s/foods/log/favorite/%d.json",
+		"CreateFood":                      "/1/user/%s/foods.json",
+		"GetMeals":                        "/1/user/%s/meals.json",
+		"GetMeal":                         "/1/user/%s/meals/%d.json",
+		"CreateMeal":                      "/1/user/%s/meals.json",
+		"UpdateMeal":                      "/1/user/%s/meals/%d.json",
+		"DeleteMeal":                      "/1/user/%s/meals/%d.json",
+		"GetWeightLogs":                   "/1/user/%s/body/log/weight/date/%s.json",
+		"LogWeight":                       "/1/user/%s/body/log/weight.json",
+		"DeleteWeightLog":                 "/1/user/%s/body/log/weight/%d.json",
+		"GetBodyFatLogs":                  "/1/user/%s/body/log/fat/date/%s.json",
+		"LogBodyFat":                      "/1/user/%s/body/log/fat.json",
+		"DeleteBodyFatLog":                "/1/user/%s/body/log/fat/%d.json",
+		"GetBodyTimeSeries":               "/1/user/%s/body/%s/date/%s/%s.json",
+		"GetDevices":                      "/1/user/%s/devices.json",
+		"GetECGLogList":                   "/1/user/%s/ecg/list.json?%s",
+		"GetAlarms":                       "/1/user/%s/devices/tracker/%s/alarms.json",
+		"AddAlarm":                        "/1/user/%s/devices/tracker/%s/alarms.json",
+		"UpdateAlarm":                     "/1/user/%s/devices/tracker/%s/alarms/%d.json",
+		"DeleteAlarm":                     "/1/user/%s/devices/tracker/%s/alarms/%d.json",
+		"GetSleepLogByDate":               "/1.2/user/%s/sleep/date/%s.json",
+		"GetSleepLogByDateRange":          "/1.2/user/%s/sleep/date/%s/%s.json",
+		"GetSleepLogList":                 "/1.2/user/%s/sleep/list.json?%s",
+		"LogSleep":                        "/1.2/user/%s/sleep.json",
+		"DeleteSleepLog":                  "/1.2/user/%s/sleep/%d.json",
+		"GetSleepGoal":                    "/1.2/user/%s/sleep/goal.json",
+		"UpdateSleepGoal":                 "/1.2/user/%s/sleep/goal.json",
+		"GetProfile":                      "/1/user/%s/profile.json",
+		"UpdateProfile":                   "/1/user/%s/profile.json",
+		"GetBadges":                       "/1/user/%s/badges.json",
+		"CreateSubscription":              "/1/user/%s/%sapiSubscriptions/%s.json",
+		"DeleteSubscription":              "/1/user/%s/%sapiSubscriptions/%s.json",
+		"ListSubscriptions":               "/1/user/%s/%sapiSubscriptions.json",
+		"GetFriends":                      "/1.1/user/%s/friends.json",
+		"GetFriendsLeaderboard":           "/1.1/user/%s/leaderboard/friends.json",
+		"GetSpO2Summary":                  "/1/user/%s/spo2/date/%s.json",
+		"GetSpO2SummaryByInterval":        "/1/user/%s/spo2/date/%s/%s.json",
+		"GetSkinTemperature":              "/1/user/%s/temp/skin/date/%s/%s.json",
+		"GetCoreTemperature":              "/1/user/%s/temp/core/date/%s/%s.json",
 	}
 )
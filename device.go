@@ -0,0 +1,278 @@
+package fitbit
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Weekday represents a day of the week used by alarms.
+type Weekday string
+
+const (
+	WeekdayMonday    Weekday = "MONDAY"
+	WeekdayTuesday   Weekday = "TUESDAY"
+	WeekdayWednesday Weekday = "WEDNESDAY"
+	WeekdayThursday  Weekday = "THURSDAY"
+	WeekdayFriday    Weekday = "FRIDAY"
+	WeekdaySaturday  Weekday = "SATURDAY"
+	WeekdaySunday    Weekday = "SUNDAY"
+)
+
+func (wd Weekday) validate() error {
+	switch wd {
+	case WeekdayMonday, WeekdayTuesday, WeekdayWednesday, WeekdayThursday, WeekdayFriday, WeekdaySaturday, WeekdaySunday:
+		return nil
+	}
+	return fmt.Errorf("fitbit: invalid weekday %q", string(wd))
+}
+
+// ErrInvalidAlarmTime is returned when the time of an alarm is not in `HH:mm-TZ:offset` format.
+var ErrInvalidAlarmTime = errors.New("fitbit: alarm time must be in HH:mm-TZ:offset format")
+
+var alarmTimePattern = regexp.MustCompile(`^([01][0-9]|2[0-3]):[0-5][0-9][+-]([01][0-9]|2[0-3]):[0-5][0-9]$`)
+
+type (
+	rawDevice struct {
+		Battery       string   `json:"battery"`
+		BatteryLevel  int64    `json:"batteryLevel"`
+		DeviceVersion string   `json:"deviceVersion"`
+		Features      []string `json:"features"`
+		ID            string   `json:"id"`
+		LastSyncTime  string   `json:"lastSyncTime"`
+		Mac           string   `json:"mac"`
+		Type          string   `json:"type"`
+	}
+
+	// Device represents a user's device paired with the account.
+	Device struct {
+		ID            string
+		DeviceVersion string
+		Type          string
+		Battery       string
+		BatteryLevel  int64
+		Features      []string
+		LastSyncTime  *time.Time // in user's local time, but the location is set to UTC
+		Mac           string
+	}
+
+	// Alarm represents an alarm set on a tracker.
+	Alarm struct {
+		AlarmID        int64     `json:"alarmId"`
+		Deleted        bool      `json:"deleted"`
+		Enabled        bool      `json:"enabled"`
+		Recurring      bool      `json:"recurring"`
+		SnoozeCount    int64     `json:"snoozeCount"`
+		SnoozeLength   int64     `json:"snoozeLength"` // in minutes
+		SyncedToDevice bool      `json:"syncedToDevice"`
+		Time           string    `json:"time"` // in HH:mm-TZ:offset format, e.g. 07:15-08:00
+		Vibe           string    `json:"vibe"`
+		WeekDays       []Weekday `json:"weekDays"`
+	}
+
+	// AlarmParams represents parameters to add or update an alarm.
+	//
+	// SnoozeLength and SnoozeCount are only used on UpdateAlarm, and required for it.
+	AlarmParams struct {
+		Time         string // in HH:mm-TZ:offset format, e.g. 07:15-08:00
+		Enabled      bool
+		Recurring    bool
+		WeekDays     []Weekday
+		SnoozeLength int64 // in minutes
+		SnoozeCount  int64
+	}
+
+	rawAlarmsResponse struct {
+		TrackerAlarms []Alarm `json:"trackerAlarms"`
+	}
+
+	rawAlarmResponse struct {
+		TrackerAlarm *Alarm `json:"trackerAlarm"`
+	}
+)
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (d *Device) UnmarshalJSON(b []byte) error {
+	var raw rawDevice
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return err
+	}
+
+	lastSyncTime, err := parseTime(localDateTimeFormat, raw.LastSyncTime)
+	if err != nil {
+		return err
+	}
+
+	d.ID = raw.ID
+	d.DeviceVersion = raw.DeviceVersion
+	d.Type = raw.Type
+	d.Battery = raw.Battery
+	d.BatteryLevel = raw.BatteryLevel
+	d.Features = raw.Features
+	d.LastSyncTime = lastSyncTime
+	d.Mac = raw.Mac
+	return nil
+}
+
+// StaleFor returns how long it has been since the device synced last at `now`.
+//
+// LastSyncTime is in the user's local time, so `now` should be located in the user's timezone,
+// e.g. time.Now().In(loc), to compare them correctly.
+// The maximum duration is returned when LastSyncTime is not available, and 0 when LastSyncTime is after `now`.
+func (d Device) StaleFor(now time.Time) time.Duration {
+	if d.LastSyncTime == nil {
+		return time.Duration(math.MaxInt64)
+	}
+	t := d.LastSyncTime
+	lastSync := time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), now.Location())
+	if stale := now.Sub(lastSync); stale > 0 {
+		return stale
+	}
+	return 0
+}
+
+// FilterStaleDevices returns the devices which have not synced for longer than `threshold` at `now`.
+//
+// `now` should be located in the user's timezone as described in Device.StaleFor.
+// An empty slice is returned when there are no such devices.
+func FilterStaleDevices(devices []Device, threshold time.Duration, now time.Time) []Device {
+	stale := []Device{}
+	for _, d := range devices {
+		if d.StaleFor(now) > threshold {
+			stale = append(stale, d)
+		}
+	}
+	return stale
+}
+
+// GetDevices retrieves a list of devices paired with the user's account.
+//
+// An empty slice is returned when the user has no devices.
+//
+// Scope.Settings is required.
+//
+// Web API Reference: https://dev.fitbit.com/build/reference/web-api/devices/get-devices/
+func (c *Client) GetDevices(ctx context.Context, userID string, token *Token) ([]Device, *RateLimit, []byte, error) {
+	endpoint := c.getEndpoint("GetDevices", resolveUserID(userID))
+	b, rateLimit, err := c.getRequest(ctx, token, endpoint)
+	if err != nil {
+		return nil, nil, b, err
+	}
+	var devices []Device
+	if err := json.Unmarshal(b, &devices); err != nil {
+		return nil, rateLimit, b, err
+	}
+	if devices == nil {
+		devices = []Device{}
+	}
+	return devices, rateLimit, b, nil
+}
+
+func (p *AlarmParams) values() (url.Values, error) {
+	if !alarmTimePattern.MatchString(p.Time) {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidAlarmTime, p.Time)
+	}
+	weekDays := make([]string, len(p.WeekDays))
+	for i, wd := range p.WeekDays {
+		if err := wd.validate(); err != nil {
+			return nil, err
+		}
+		weekDays[i] = string(wd)
+	}
+	values := url.Values{}
+	values.Set("time", p.Time)
+	values.Set("enabled", strconv.FormatBool(p.Enabled))
+	values.Set("recurring", strconv.FormatBool(p.Recurring))
+	values.Set("weekDays", strings.Join(weekDays, ","))
+	return values, nil
+}
+
+// GetAlarms retrieves a list of alarms set on a tracker.
+//
+// Scope.Settings is required.
+//
+// Web API Reference: https://dev.fitbit.com/build/reference/web-api/devices/get-alarms/
+func (c *Client) GetAlarms(ctx context.Context, userID, trackerID string, token *Token) ([]Alarm, *RateLimit, []byte, error) {
+	endpoint := c.getEndpoint("GetAlarms", resolveUserID(userID), trackerID)
+	b, rateLimit, err := c.getRequest(ctx, token, endpoint)
+	if err != nil {
+		return nil, nil, b, err
+	}
+	var resp rawAlarmsResponse
+	if err := json.Unmarshal(b, &resp); err != nil {
+		return nil, rateLimit, b, err
+	}
+	if resp.TrackerAlarms == nil {
+		resp.TrackerAlarms = []Alarm{}
+	}
+	return resp.TrackerAlarms, rateLimit, b, nil
+}
+
+// AddAlarm adds an alarm to a tracker.
+//
+// Scope.Settings is required.
+//
+// Web API Reference: https://dev.fitbit.com/build/reference/web-api/devices/add-alarms/
+func (c *Client) AddAlarm(ctx context.Context, userID, trackerID string, params AlarmParams, token *Token) (*Alarm, *RateLimit, []byte, error) {
+	values, err := params.values()
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	endpoint := c.getEndpoint("AddAlarm", resolveUserID(userID), trackerID)
+	return c.postAlarm(ctx, token, endpoint, values)
+}
+
+// UpdateAlarm updates an alarm set on a tracker.
+//
+// Scope.Settings is required.
+//
+// Web API Reference: https://dev.fitbit.com/build/reference/web-api/devices/update-alarms/
+func (c *Client) UpdateAlarm(ctx context.Context, userID, trackerID string, alarmID int64, params AlarmParams, token *Token) (*Alarm, *RateLimit, []byte, error) {
+	if err := validateAlarmID(alarmID); err != nil {
+		return nil, nil, nil, err
+	}
+	values, err := params.values()
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	values.Set("snoozeLength", strconv.FormatInt(params.SnoozeLength, 10))
+	values.Set("snoozeCount", strconv.FormatInt(params.SnoozeCount, 10))
+	endpoint := c.getEndpoint("UpdateAlarm", resolveUserID(userID), trackerID, alarmID)
+	return c.postAlarm(ctx, token, endpoint, values)
+}
+
+func (c *Client) postAlarm(ctx context.Context, token *Token, endpoint endpointURL, values url.Values) (*Alarm, *RateLimit, []byte, error) {
+	b, rateLimit, err := c.postRequest(ctx, token, endpoint, values)
+	if err != nil {
+		return nil, nil, b, err
+	}
+	var resp rawAlarmResponse
+	if err := json.Unmarshal(b, &resp); err != nil {
+		return nil, rateLimit, b, err
+	}
+	return resp.TrackerAlarm, rateLimit, b, nil
+}
+
+// DeleteAlarm deletes an alarm set on a tracker.
+//
+// Scope.Settings is required.
+//
+// Web API Reference: https://dev.fitbit.com/build/reference/web-api/devices/delete-alarms/
+func (c *Client) DeleteAlarm(ctx context.Context, userID, trackerID string, alarmID int64, token *Token) (*RateLimit, error) {
+	if err := validateAlarmID(alarmID); err != nil {
+		return nil, err
+	}
+	endpoint := c.getEndpoint("DeleteAlarm", resolveUserID(userID), trackerID, alarmID)
+	_, rateLimit, err := c.deleteRequest(ctx, token, endpoint)
+	if err != nil {
+		return nil, err
+	}
+	return rateLimit, nil
+}
@@ -0,0 +1,120 @@
+package fitbit
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+)
+
+// MaxECGLogListLimit is the maximum number of ECG readings which can be retrieved at once.
+const MaxECGLogListLimit = 10
+
+type (
+	// ECGListParams represents parameters to retrieve a list of ECG readings.
+	//
+	// Either of BeforeDate or AfterDate is required.
+	// Sort must be SortDescending with BeforeDate, and SortAscending with AfterDate.
+	// It is set accordingly when it is empty.
+	// Limit must be between 1 and MaxECGLogListLimit.
+	ECGListParams struct {
+		BeforeDate *time.Time
+		AfterDate  *time.Time
+		Sort       SortOrder
+		Limit      int
+		Offset     int // Fitbit only supports 0
+	}
+
+	rawECGReading struct {
+		StartTime               string      `json:"startTime"`
+		AverageHeartRate        int64       `json:"averageHeartRate"`
+		ResultClassification    string      `json:"resultClassification"`
+		WaveformSamples         []float64   `json:"waveformSamples"`
+		SamplingFrequencyHz     json.Number `json:"samplingFrequencyHz"`
+		ScalingFactor           int64       `json:"scalingFactor"`
+		NumberOfWaveformSamples int64       `json:"numberOfWaveformSamples"`
+		LeadNumber              int64       `json:"leadNumber"`
+		FeatureVersion          string      `json:"featureVersion"`
+		DeviceName              string      `json:"deviceName"`
+		FirmwareVersion         string      `json:"firmwareVersion"`
+	}
+
+	// ECGReading represents a reading of the ECG app.
+	ECGReading struct {
+		StartTime               *time.Time // in user's local time, but the location is set to UTC
+		AverageHeartRate        int64
+		ResultClassification    string
+		WaveformSamples         []float64
+		SamplingFrequency       float64 // in Hz
+		ScalingFactor           int64
+		NumberOfWaveformSamples int64
+		LeadNumber              int64
+		FeatureVersion          string
+		DeviceName              string
+		FirmwareVersion         string
+	}
+
+	// ECGLogList represents a page of ECG readings.
+	ECGLogList struct {
+		ECGReadings []ECGReading `json:"ecgReadings"`
+		Pagination  *Pagination  `json:"pagination"`
+	}
+)
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (r *ECGReading) UnmarshalJSON(b []byte) error {
+	var raw rawECGReading
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return err
+	}
+
+	startTime, err := parseTime(localDateTimeFormat, raw.StartTime)
+	if err != nil {
+		return err
+	}
+	var samplingFrequency float64
+	if raw.SamplingFrequencyHz != "" {
+		if samplingFrequency, err = raw.SamplingFrequencyHz.Float64(); err != nil {
+			return err
+		}
+	}
+
+	r.StartTime = startTime
+	r.AverageHeartRate = raw.AverageHeartRate
+	r.ResultClassification = raw.ResultClassification
+	r.WaveformSamples = raw.WaveformSamples
+	r.SamplingFrequency = samplingFrequency
+	r.ScalingFactor = raw.ScalingFactor
+	r.NumberOfWaveformSamples = raw.NumberOfWaveformSamples
+	r.LeadNumber = raw.LeadNumber
+	r.FeatureVersion = raw.FeatureVersion
+	r.DeviceName = raw.DeviceName
+	r.FirmwareVersion = raw.FirmwareVersion
+	return nil
+}
+
+// GetECGLogList retrieves a page of the user's ECG readings.
+//
+// Pagination of the result holds the URLs of the next and previous pages.
+//
+// Scope.Electrocardiogram is required.
+//
+// Web API Reference: https://dev.fitbit.com/build/reference/web-api/electrocardiogram/get-ecg-log-list/
+func (c *Client) GetECGLogList(ctx context.Context, userID string, params ECGListParams, token *Token) (*ECGLogList, *RateLimit, []byte, error) {
+	query, err := listQuery(params.BeforeDate, params.AfterDate, params.Sort, params.Limit, params.Offset, MaxECGLogListLimit)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	endpoint := c.getEndpoint("GetECGLogList", resolveUserID(userID), query.Encode())
+	b, rateLimit, err := c.getRequest(ctx, token, endpoint)
+	if err != nil {
+		return nil, nil, b, err
+	}
+	var list ECGLogList
+	if err := json.Unmarshal(b, &list); err != nil {
+		return nil, rateLimit, b, err
+	}
+	if list.ECGReadings == nil {
+		list.ECGReadings = []ECGReading{}
+	}
+	return &list, rateLimit, b, nil
+}
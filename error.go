@@ -2,11 +2,18 @@ package fitbit
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
+	"sort"
 	"strings"
+
+	"golang.org/x/oauth2"
 )
 
+// ErrNilToken is returned when a nil token is given where a token is required.
+var ErrNilToken = errors.New("fitbit: token is nil")
+
 // Error is the interface that has ability to return raw error returned from Fitbit APIs.
 //
 // This also implements the builtin error interface.
@@ -175,16 +182,99 @@ func parseErrorResponse(b []byte) (*ErrorResponse, error) {
 }
 
 // APIError represents an error that occurred on a request to Fitbit APIs.
+//
+// ErrResp.Errors holds the errors returned from Fitbit APIs,
+// and the predicates like IsExpiredToken can be used to branch on them.
 type APIError struct {
-	ErrResp  *ErrorResponse
-	HTTPResp *http.Response
-	Body     []byte
+	StatusCode int
+	ErrResp    *ErrorResponse
+	HTTPResp   *http.Response
+	Body       []byte
+
+	verbose bool
+}
+
+// errorTypes returns the `errorType` fields of the errors returned from Fitbit APIs.
+func (ae *APIError) errorTypes() []string {
+	if ae.ErrResp == nil {
+		return nil
+	}
+	types := make([]string, 0, len(ae.ErrResp.Errors))
+	for _, e := range ae.ErrResp.Errors {
+		switch e := e.(type) {
+		case *MessageError:
+			types = append(types, e.Type)
+		case *FieldNameMessageError:
+			types = append(types, e.Type)
+		}
+	}
+	return types
+}
+
+// HasErrorType reports whether Fitbit APIs returned an error of the specified `errorType`,
+// like "expired_token" or "validation".
+func (ae *APIError) HasErrorType(errorType string) bool {
+	for _, t := range ae.errorTypes() {
+		if t == errorType {
+			return true
+		}
+	}
+	return false
+}
+
+// IsExpiredToken reports whether the access token has expired.
+func (ae *APIError) IsExpiredToken() bool {
+	return ae.HasErrorType("expired_token")
+}
+
+// IsInvalidToken reports whether the access token or refresh token is invalid.
+func (ae *APIError) IsInvalidToken() bool {
+	return ae.HasErrorType("invalid_token") || ae.HasErrorType("invalid_grant")
+}
+
+// IsInsufficientScope reports whether the token does not have the scope required by the endpoint.
+func (ae *APIError) IsInsufficientScope() bool {
+	return ae.HasErrorType("insufficient_scope") || ae.HasErrorType("insufficient_permissions")
+}
+
+// IsRateLimited reports whether the request was rejected due to the rate limit.
+func (ae *APIError) IsRateLimited() bool {
+	return ae.StatusCode == http.StatusTooManyRequests
+}
+
+// IsReauthRequired reports whether `err` indicates that the user has to link the application again,
+// e.g. the access has been revoked by the user or the refresh token is no longer valid.
+//
+// Retrying the request does not help in that case, so the user should be led to the authorization page.
+func IsReauthRequired(err error) bool {
+	if apiErr := (*APIError)(nil); errors.As(err, &apiErr) {
+		return apiErr.IsInvalidToken()
+	}
+	// The error on token refresh may not have been parsed yet when it comes from HTTPClient.
+	if rErr := (*oauth2.RetrieveError)(nil); errors.As(err, &rErr) {
+		if e := parseError(rErr.Response, rErr.Body); e != nil {
+			return IsReauthRequired(e)
+		}
+	}
+	return false
 }
 
 // Error implements the error interface.
+//
+// In debug mode, the status code and the raw response body are appended.
 func (ae *APIError) Error() string {
+	if ae.verbose {
+		return fmt.Sprintf("%s (status: %d, body: %s)", ae.message(), ae.StatusCode, string(ae.Body))
+	}
+	return ae.message()
+}
+
+func (ae *APIError) message() string {
 	if ae.ErrResp == nil || len(ae.ErrResp.Errors) == 0 {
-		return ae.HTTPResp.Status
+		if ae.HTTPResp != nil {
+			return ae.HTTPResp.Status
+		}
+		return fmt.Sprintf("%d %s", ae.StatusCode, http.StatusText(ae.StatusCode))
 	}
 	errMsgs := make([]string, len(ae.ErrResp.Errors))
 	for i, e := range ae.ErrResp.Errors {
@@ -221,17 +311,87 @@ func (e *RequestError) Error() string {
 	return fmt.Sprintf("%s %q: %s", e.Op, e.URL, e.Err)
 }
 
+// PermissionError represents an error that the request was forbidden by Fitbit APIs,
+// e.g. the application is not permitted to access intraday data.
+type PermissionError struct {
+	Err error
+}
+
+func wrapAsPermissionError(err error) error {
+	if apiErr := (*APIError)(nil); errors.As(err, &apiErr) {
+		if apiErr.StatusCode == http.StatusForbidden {
+			return &PermissionError{Err: err}
+		}
+	}
+	return err
+}
+
+// Unwrap adds support for `errors` error wrapping.
+func (e *PermissionError) Unwrap() error {
+	return e.Err
+}
+
+// Error implements the error interface.
+func (e *PermissionError) Error() string {
+	return fmt.Sprintf("fitbit: permission denied: %s", e.Err)
+}
+
+// TokenPersistError represents an error returned by the function set by `SetUpdateTokenFunc`
+// on token refresh.
+//
+// The token has been refreshed by Fitbit at that point and the old refresh token is no longer valid,
+// so NewToken should be saved by other means to keep access.
+type TokenPersistError struct {
+	OldToken *Token
+	NewToken *Token
+	Err      error
+}
+
+// Unwrap adds support for `errors` error wrapping.
+func (e *TokenPersistError) Unwrap() error {
+	return e.Err
+}
+
+// Error implements the error interface.
+func (e *TokenPersistError) Error() string {
+	return fmt.Sprintf("fitbit(oauth2): cannot persist refreshed token: %s", e.Err)
+}
+
+// BatchError represents errors that occurred in a batch of requests,
+// keyed by the item of each failed request, like a date or a user ID.
+type BatchError struct {
+	Errors map[string]error
+}
+
+// Error implements the error interface.
+func (e *BatchError) Error() string {
+	keys := make([]string, 0, len(e.Errors))
+	for key := range e.Errors {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	errMsgs := make([]string, len(keys))
+	for i, key := range keys {
+		errMsgs[i] = fmt.Sprintf("%s: %s", key, e.Errors[key])
+	}
+	return fmt.Sprintf("fitbit: %d of the requests failed:\n%s", len(keys), strings.Join(errMsgs, "\n"))
+}
+
 func parseError(r *http.Response, b []byte) error {
 	errResp, err := parseErrorResponse(b)
 	if err != nil {
 		return err
 	}
 	if errResp == nil || !errResp.Success {
-		return &APIError{
+		apiErr := &APIError{
 			ErrResp:  errResp,
 			HTTPResp: r,
 			Body:     b,
 		}
+		if r != nil {
+			apiErr.StatusCode = r.StatusCode
+		}
+		return apiErr
 	}
 	return nil
 }
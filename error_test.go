@@ -0,0 +1,109 @@
+package fitbit
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+)
+
+func TestAPIErrorPredicates(t *testing.T) {
+	tests := []struct {
+		name                  string
+		statusCode            int
+		body                  string
+		wantExpiredToken      bool
+		wantInvalidToken      bool
+		wantInsufficientScope bool
+		wantRateLimited       bool
+	}{
+		{
+			name:             "expired access token",
+			statusCode:       http.StatusUnauthorized,
+			body:             `{"errors":[{"errorType":"expired_token","message":"Access token expired: eyJhbGciOiJIUzI1NiJ9. Visit https://dev.fitbit.com/docs/oauth2 for more information on the Fitbit Web API authorization process."}],"success":false}`,
+			wantExpiredToken: true,
+		},
+		{
+			name:             "invalid access token",
+			statusCode:       http.StatusUnauthorized,
+			body:             `{"errors":[{"errorType":"invalid_token","message":"Access token invalid: eyJhbGciOiJIUzI1NiJ9. Visit https://dev.fitbit.com/docs/oauth2 for more information on the Fitbit Web API authorization process."}],"success":false}`,
+			wantInvalidToken: true,
+		},
+		{
+			name:             "invalid refresh token on token endpoint",
+			statusCode:       http.StatusBadRequest,
+			body:             `{"errors":[{"errorType":"invalid_grant","message":"Refresh token invalid: 8fd2bc5a0c8e1b2c1e36b07ed0a4d2e7. Visit https://dev.fitbit.com/docs/oauth2 for more information on the Fitbit Web API authorization process."}],"success":false}`,
+			wantInvalidToken: true,
+		},
+		{
+			name:                  "insufficient scope",
+			statusCode:            http.StatusForbidden,
+			body:                  `{"errors":[{"errorType":"insufficient_scope","message":"This application does not have permission to access heartrate data. Visit https://dev.fitbit.com/docs/oauth2 for more information on the Fitbit Web API authorization process."}],"success":false}`,
+			wantInsufficientScope: true,
+		},
+		{
+			name:                  "insufficient permissions",
+			statusCode:            http.StatusForbidden,
+			body:                  `{"errors":[{"errorType":"insufficient_permissions","message":"Read-only API client is not authorized to update resources. Visit https://dev.fitbit.com/docs/oauth2 for more information on the Fitbit Web API authorization process."}],"success":false}`,
+			wantInsufficientScope: true,
+		},
+		{
+			name:            "rate limited",
+			statusCode:      http.StatusTooManyRequests,
+			body:            `{"errors":[{"errorType":"system","fieldName":"n/a","message":"Too Many Requests"}],"success":false}`,
+			wantRateLimited: true,
+		},
+		{
+			name:       "validation",
+			statusCode: http.StatusBadRequest,
+			body:       `{"errors":[{"errorType":"validation","fieldName":"date","message":"Invalid date:2016-13-01"}],"success":false}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := parseError(&http.Response{StatusCode: tt.statusCode}, []byte(tt.body))
+			var apiErr *APIError
+			if !errors.As(err, &apiErr) {
+				t.Fatalf("parseError() = %v, want *APIError", err)
+			}
+			if apiErr.StatusCode != tt.statusCode {
+				t.Errorf("StatusCode = %d, want %d", apiErr.StatusCode, tt.statusCode)
+			}
+			if got := apiErr.IsExpiredToken(); got != tt.wantExpiredToken {
+				t.Errorf("IsExpiredToken() = %v, want %v", got, tt.wantExpiredToken)
+			}
+			if got := apiErr.IsInvalidToken(); got != tt.wantInvalidToken {
+				t.Errorf("IsInvalidToken() = %v, want %v", got, tt.wantInvalidToken)
+			}
+			if got := apiErr.IsInsufficientScope(); got != tt.wantInsufficientScope {
+				t.Errorf("IsInsufficientScope() = %v, want %v", got, tt.wantInsufficientScope)
+			}
+			if got := apiErr.IsRateLimited(); got != tt.wantRateLimited {
+				t.Errorf("IsRateLimited() = %v, want %v", got, tt.wantRateLimited)
+			}
+			if got := IsReauthRequired(wrapAsRequestError("Get", "https://api.fitbit.com", err)); got != tt.wantInvalidToken {
+				t.Errorf("IsReauthRequired() = %v, want %v", got, tt.wantInvalidToken)
+			}
+		})
+	}
+}
+
+func TestParseErrorFieldName(t *testing.T) {
+	err := parseError(&http.Response{StatusCode: http.StatusBadRequest}, []byte(`{"errors":[{"errorType":"validation","fieldName":"date","message":"Invalid date:2016-13-01"}],"success":false}`))
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("parseError() = %v, want *APIError", err)
+	}
+	if len(apiErr.ErrResp.Errors) != 1 {
+		t.Fatalf("len(Errors) = %d, want 1", len(apiErr.ErrResp.Errors))
+	}
+	fieldErr, ok := apiErr.ErrResp.Errors[0].(*FieldNameMessageError)
+	if !ok {
+		t.Fatalf("Errors[0] has type %T, want *FieldNameMessageError", apiErr.ErrResp.Errors[0])
+	}
+	if fieldErr.Type != "validation" || fieldErr.FieldName != "date" {
+		t.Errorf("got type %q and field %q, want %q and %q", fieldErr.Type, fieldErr.FieldName, "validation", "date")
+	}
+	if got, want := apiErr.Error(), "Invalid date:2016-13-01"; got != want {
+		t.Errorf("Error() = %q, want %q", got, want)
+	}
+}
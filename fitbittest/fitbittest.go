@@ -0,0 +1,125 @@
+// Package fitbittest provides an in-memory mock of Fitbit APIs for testing code that uses the fitbit package.
+package fitbittest
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"path"
+	"sync"
+
+	"github.com/anyappinc/fitbit"
+)
+
+const (
+	UserID       = "ABC123"            // UserID is the user ID of the canned responses
+	AccessToken  = "access-token"      // AccessToken is the access token issued by the canned token endpoint
+	RefreshToken = "refresh-token"     // RefreshToken is the refresh token issued by the canned token endpoint
+	AuthPath     = "/oauth2/authorize" // AuthPath is the path of the authorization endpoint
+	TokenPath    = "/oauth2/token"     // TokenPath is the path of the token endpoint
+)
+
+// Canned responses served by Server unless overridden.
+const (
+	TokenResponse = `{"access_token":"` + AccessToken + `","expires_in":28800,"refresh_token":"` + RefreshToken + `","scope":"activity heartrate location nutrition profile settings sleep social weight","token_type":"Bearer","user_id":"` + UserID + `"}`
+
+	ProfileResponse = `{"user":{"encodedId":"` + UserID + `","displayName":"Test User","fullName":"Test User","firstName":"Test","lastName":"User","gender":"NA","dateOfBirth":"1990-01-01","age":30,"timezone":"UTC","offsetFromUTCMillis":0,"height":170,"weight":60,"memberSince":"2020-01-01","locale":"en_US","languageLocale":"en_US","foodsLocale":"en_US","distanceUnit":"METRIC","heightUnit":"METRIC","weightUnit":"METRIC","topBadges":[]}}`
+
+	DailyActivitySummaryResponse = `{"activities":[],"goals":{"activeMinutes":30,"caloriesOut":2000,"distance":8.05,"floors":10,"steps":10000},"summary":{"activityCalories":500,"caloriesBMR":1500,"caloriesOut":2000,"distances":[{"activity":"total","distance":5.2}],"floors":5,"steps":7000,"sedentaryMinutes":600,"lightlyActiveMinutes":180,"fairlyActiveMinutes":20,"veryActiveMinutes":15}}`
+)
+
+type route struct {
+	method  string
+	pattern string
+	handler http.Handler
+}
+
+// Server is a mock server of Fitbit APIs, which serves the canned responses
+// for the token endpoint, GetProfile and GetDailyActivitySummary.
+//
+// Handlers registered later take precedence, so the canned responses can be overridden.
+type Server struct {
+	*httptest.Server
+
+	mu     sync.RWMutex
+	routes []route
+}
+
+// NewServer starts and returns a new Server.
+// The caller should call Close when finished, to shut it down.
+func NewServer() *Server {
+	s := &Server{}
+	s.HandleJSON(http.MethodPost, TokenPath, http.StatusOK, TokenResponse)
+	s.HandleJSON(http.MethodGet, "/1/user/*/profile.json", http.StatusOK, ProfileResponse)
+	s.HandleJSON(http.MethodGet, "/1/user/*/activities/date/*.json", http.StatusOK, DailyActivitySummaryResponse)
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	return s
+}
+
+// Configure points the client to the server.
+func (s *Server) Configure(c *fitbit.Client) error {
+	if err := c.SetAPIBaseURL(s.URL); err != nil {
+		return err
+	}
+	return c.SetOAuth2Endpoint(s.URL+AuthPath, s.URL+TokenPath)
+}
+
+// Token returns the token issued by the canned token endpoint.
+func (s *Server) Token() *fitbit.Token {
+	return &fitbit.Token{
+		AccessToken:  AccessToken,
+		TokenType:    "Bearer",
+		RefreshToken: RefreshToken,
+	}
+}
+
+// Handle registers the handler for requests of `method` to the path matching `pattern`.
+//
+// `pattern` is matched by path.Match, so that "/1/user/*/profile.json" matches any user ID.
+// An empty `method` matches any method.
+func (s *Server) Handle(method, pattern string, handler http.Handler) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.routes = append(s.routes, route{
+		method:  method,
+		pattern: pattern,
+		handler: handler,
+	})
+}
+
+// HandleFunc registers the handler function in the same way as Handle.
+func (s *Server) HandleFunc(method, pattern string, handler func(http.ResponseWriter, *http.Request)) {
+	s.Handle(method, pattern, http.HandlerFunc(handler))
+}
+
+// HandleJSON registers the handler which responds with `body` and `statusCode` in the same way as Handle.
+func (s *Server) HandleJSON(method, pattern string, statusCode int, body string) {
+	s.HandleFunc(method, pattern, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json;charset=UTF-8")
+		w.WriteHeader(statusCode)
+		w.Write([]byte(body))
+	})
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.RLock()
+	var handler http.Handler
+	for i := len(s.routes) - 1; i >= 0; i-- {
+		rt := s.routes[i]
+		if rt.method != "" && rt.method != r.Method {
+			continue
+		}
+		if ok, _ := path.Match(rt.pattern, r.URL.Path); ok {
+			handler = rt.handler
+			break
+		}
+	}
+	s.mu.RUnlock()
+
+	if handler == nil {
+		w.Header().Set("Content-Type", "application/json;charset=UTF-8")
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"errors":[{"errorType":"not_found","message":"The resource requested was not found."}],"success":false}`))
+		return
+	}
+	handler.ServeHTTP(w, r)
+}
@@ -0,0 +1,173 @@
+package fitbit
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+)
+
+// MaxHeartRateTimeSeriesDateRange is the maximum number of days that can be requested at once by GetHeartRateTimeSeries.
+const MaxHeartRateTimeSeriesDateRange = 365
+
+type (
+	rawHeartRateDayValue struct {
+		CustomHeartRateZones []HeartRateZone `json:"customHeartRateZones"`
+		HeartRateZones       []HeartRateZone `json:"heartRateZones"`
+		RestingHeartRate     *int64          `json:"restingHeartRate"`
+	}
+
+	rawHeartRateDay struct {
+		rawHeartRateDayValue
+		DateTime string          `json:"dateTime"`
+		Value    json.RawMessage `json:"value"`
+	}
+
+	// HeartRateDay represents a user's heart rate data on a date.
+	//
+	// RestingHeartRate is nil when it is not available on the date.
+	HeartRateDay struct {
+		Date                 *time.Time
+		RestingHeartRate     *int64
+		HeartRateZones       []HeartRateZone
+		CustomHeartRateZones []HeartRateZone
+	}
+
+	rawHeartRateTimeSeries struct {
+		ActivitiesHeart []HeartRateDay `json:"activities-heart"`
+	}
+
+	rawHeartRateIntraday struct {
+		ActivitiesHeart         []HeartRateDay     `json:"activities-heart"`
+		ActivitiesHeartIntraday rawIntradayDataset `json:"activities-heart-intraday"`
+	}
+
+	// HeartRateIntraday represents a user's intraday heart rate data on a date.
+	HeartRateIntraday struct {
+		Day             *HeartRateDay
+		Dataset         []IntradayPoint
+		DatasetInterval int64
+		DatasetType     string
+	}
+)
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (d *HeartRateDay) UnmarshalJSON(b []byte) error {
+	var raw rawHeartRateDay
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return err
+	}
+
+	date, err := parseTime(dateFormat, raw.DateTime)
+	if err != nil {
+		return err
+	}
+	// The heart rate zones are held by `value` on time series,
+	// but they are placed at the top level on intraday.
+	value := raw.rawHeartRateDayValue
+	if len(raw.Value) > 0 && raw.Value[0] == '{' {
+		if err := json.Unmarshal(raw.Value, &value); err != nil {
+			return err
+		}
+	}
+
+	d.Date = date
+	d.RestingHeartRate = value.RestingHeartRate
+	d.HeartRateZones = value.HeartRateZones
+	d.CustomHeartRateZones = value.CustomHeartRateZones
+	return nil
+}
+
+// GetHeartRateTimeSeries retrieves the heart rate data over a date range.
+//
+// The date range must not exceed MaxHeartRateTimeSeriesDateRange days.
+//
+// Scope.Heartrate is required.
+//
+// Web API Reference: https://dev.fitbit.com/build/reference/web-api/heartrate-timeseries/get-heartrate-timeseries-by-date-range/
+func (c *Client) GetHeartRateTimeSeries(ctx context.Context, userID string, start, end time.Time, token *Token) ([]HeartRateDay, *RateLimit, []byte, error) {
+	if err := validateDateRange(start, end, MaxHeartRateTimeSeriesDateRange); err != nil {
+		return nil, nil, nil, err
+	}
+	endpoint := c.getEndpoint("GetHeartRateTimeSeries", resolveUserID(userID), start.Format(dateFormat), end.Format(dateFormat))
+	b, rateLimit, err := c.getRequest(ctx, token, endpoint)
+	if err != nil {
+		return nil, nil, b, err
+	}
+	var raw rawHeartRateTimeSeries
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return nil, rateLimit, b, err
+	}
+	if raw.ActivitiesHeart == nil {
+		raw.ActivitiesHeart = []HeartRateDay{}
+	}
+	return raw.ActivitiesHeart, rateLimit, b, nil
+}
+
+// GetHeartRateIntraday retrieves the intraday heart rate data on a date.
+//
+// The timestamps are located in UTC by default, and `opts` can specify the user's timezone or request UTC timestamps.
+//
+// Scope.Heartrate is required.
+//
+// Access to intraday data requires permission from Fitbit for Server and Client applications.
+// When it is not permitted, *PermissionError is returned.
+//
+// Web API Reference: https://dev.fitbit.com/build/reference/web-api/intraday/get-heartrate-intraday-by-date/
+func (c *Client) GetHeartRateIntraday(ctx context.Context, userID string, date time.Time, detail IntradayDetail, token *Token, opts ...IntradayOption) (*HeartRateIntraday, *RateLimit, []byte, error) {
+	if err := detail.validate(Detail1sec, Detail1min, Detail5min, Detail15min); err != nil {
+		return nil, nil, nil, err
+	}
+	endpoint := c.getEndpoint("GetHeartRateIntraday", resolveUserID(userID), date.Format(dateFormat), detail)
+	return c.getHeartRateIntraday(ctx, userID, date, endpoint, token, opts)
+}
+
+// GetHeartRateIntradayByInterval retrieves the intraday heart rate data within the time window on a date.
+//
+// `detail` must be either Detail1sec or Detail1min.
+// The timestamps are located in UTC by default, and `opts` can specify the user's timezone or request UTC timestamps.
+//
+// Scope.Heartrate is required.
+//
+// Access to intraday data requires permission from Fitbit for Server and Client applications.
+// When it is not permitted, *PermissionError is returned.
+//
+// Web API Reference: https://dev.fitbit.com/build/reference/web-api/intraday/get-heartrate-intraday-by-interval/
+func (c *Client) GetHeartRateIntradayByInterval(ctx context.Context, userID string, date time.Time, window IntradayTimeWindow, detail IntradayDetail, token *Token, opts ...IntradayOption) (*HeartRateIntraday, *RateLimit, []byte, error) {
+	if err := window.validate(); err != nil {
+		return nil, nil, nil, err
+	}
+	if err := detail.validate(Detail1sec, Detail1min); err != nil {
+		return nil, nil, nil, err
+	}
+	endpoint := c.getEndpoint("GetHeartRateIntradayByInterval", resolveUserID(userID), date.Format(dateFormat), detail, window.Start.Format("15:04"), window.End.Format("15:04"))
+	return c.getHeartRateIntraday(ctx, userID, date, endpoint, token, opts)
+}
+
+func (c *Client) getHeartRateIntraday(ctx context.Context, userID string, date time.Time, endpoint endpointURL, token *Token, opts []IntradayOption) (*HeartRateIntraday, *RateLimit, []byte, error) {
+	loc, err := c.intradayLocation(ctx, userID, token, opts)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	endpoint.url += newIntradayOptions(opts).query()
+	b, rateLimit, err := c.getRequest(ctx, token, endpoint)
+	if err != nil {
+		return nil, nil, b, wrapAsPermissionError(err)
+	}
+	var raw rawHeartRateIntraday
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return nil, rateLimit, b, err
+	}
+	dataset, err := raw.ActivitiesHeartIntraday.points(date, loc)
+	if err != nil {
+		return nil, rateLimit, b, err
+	}
+	heartRateIntraday := &HeartRateIntraday{
+		Dataset:         dataset,
+		DatasetInterval: raw.ActivitiesHeartIntraday.DatasetInterval,
+		DatasetType:     raw.ActivitiesHeartIntraday.DatasetType,
+	}
+	if len(raw.ActivitiesHeart) > 0 {
+		heartRateIntraday.Day = &raw.ActivitiesHeart[0]
+	}
+	return heartRateIntraday, rateLimit, b, nil
+}
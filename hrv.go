@@ -0,0 +1,141 @@
+package fitbit
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+)
+
+type (
+	rawHRVSummary struct {
+		DateTime string `json:"dateTime"`
+		Value    struct {
+			DailyRMSSD float64 `json:"dailyRmssd"`
+			DeepRMSSD  float64 `json:"deepRmssd"`
+		} `json:"value"`
+	}
+
+	rawHRVSummaryResponse struct {
+		HRV []HRVSummary `json:"hrv"`
+	}
+
+	// HRVSummary represents a daily summary of heart rate variability (HRV).
+	HRVSummary struct {
+		Date       *time.Time
+		DailyRMSSD float64 // in milliseconds
+		DeepRMSSD  float64 // in milliseconds
+	}
+
+	rawHRVPoint struct {
+		Minute string `json:"minute"`
+		Value  struct {
+			RMSSD    float64 `json:"rmssd"`
+			Coverage float64 `json:"coverage"`
+			HF       float64 `json:"hf"`
+			LF       float64 `json:"lf"`
+		} `json:"value"`
+	}
+
+	rawHRVIntradayResponse struct {
+		HRV []struct {
+			DateTime string        `json:"dateTime"`
+			Minutes  []rawHRVPoint `json:"minutes"`
+		} `json:"hrv"`
+	}
+
+	// HRVPoint represents a heart rate variability data point recorded during sleep.
+	HRVPoint struct {
+		Minute   time.Time // in user's local time, located in UTC unless IntradayOption specifies the timezone
+		RMSSD    float64   // in milliseconds
+		Coverage float64   // ratio of data points used for the calculation
+		HF       float64   // power in the high frequency band
+		LF       float64   // power in the low frequency band
+	}
+)
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (s *HRVSummary) UnmarshalJSON(b []byte) error {
+	var raw rawHRVSummary
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return err
+	}
+
+	date, err := parseTime(dateFormat, raw.DateTime)
+	if err != nil {
+		return err
+	}
+
+	s.Date = date
+	s.DailyRMSSD = raw.Value.DailyRMSSD
+	s.DeepRMSSD = raw.Value.DeepRMSSD
+	return nil
+}
+
+// GetHRVSummary retrieves the HRV summary of the specified date.
+//
+// nil is returned when there is no data for the date.
+//
+// Scope.Heartrate is required.
+//
+// Web API Reference: https://dev.fitbit.com/build/reference/web-api/heartrate-variability/get-hrv-summary-by-date/
+func (c *Client) GetHRVSummary(ctx context.Context, userID string, date time.Time, token *Token) (*HRVSummary, *RateLimit, []byte, error) {
+	endpoint := c.getEndpoint("GetHRVSummary", resolveUserID(userID), date.Format(dateFormat))
+	b, rateLimit, err := c.getRequest(ctx, token, endpoint)
+	if err != nil {
+		return nil, nil, b, err
+	}
+	var raw rawHRVSummaryResponse
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return nil, rateLimit, b, err
+	}
+	if len(raw.HRV) == 0 {
+		return nil, rateLimit, b, nil
+	}
+	return &raw.HRV[0], rateLimit, b, nil
+}
+
+// GetHRVIntraday retrieves the HRV data points recorded during the main sleep of the specified date.
+//
+// Each point is calculated over a 5-minute window.
+// The points may start on the day before `date` since the sleep starts in the previous evening.
+// An empty slice is returned when there is no data for the date.
+// The timestamps are located in UTC by default, and `opts` can specify the user's timezone.
+//
+// Scope.Heartrate is required.
+//
+// Web API Reference: https://dev.fitbit.com/build/reference/web-api/intraday/get-hrv-intraday-by-date/
+func (c *Client) GetHRVIntraday(ctx context.Context, userID string, date time.Time, token *Token, opts ...IntradayOption) ([]HRVPoint, *RateLimit, []byte, error) {
+	if err := rejectUTCTimestamps(opts); err != nil {
+		return nil, nil, nil, err
+	}
+	loc, err := c.intradayLocation(ctx, userID, token, opts)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	endpoint := c.getEndpoint("GetHRVIntraday", resolveUserID(userID), date.Format(dateFormat))
+	b, rateLimit, err := c.getRequest(ctx, token, endpoint)
+	if err != nil {
+		return nil, nil, b, err
+	}
+	var raw rawHRVIntradayResponse
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return nil, rateLimit, b, err
+	}
+	points := []HRVPoint{}
+	for _, day := range raw.HRV {
+		for _, m := range day.Minutes {
+			minute, err := time.ParseInLocation(localDateTimeFormat, m.Minute, loc)
+			if err != nil {
+				return nil, rateLimit, b, err
+			}
+			points = append(points, HRVPoint{
+				Minute:   minute,
+				RMSSD:    m.Value.RMSSD,
+				Coverage: m.Value.Coverage,
+				HF:       m.Value.HF,
+				LF:       m.Value.LF,
+			})
+		}
+	}
+	return points, rateLimit, b, nil
+}
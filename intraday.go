@@ -0,0 +1,191 @@
+package fitbit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// IntradayDetail represents the detail level of intraday data.
+type IntradayDetail string
+
+const (
+	Detail1sec  IntradayDetail = "1sec"  // Detail1sec represents 1 second detail level
+	Detail1min  IntradayDetail = "1min"  // Detail1min represents 1 minute detail level
+	Detail5min  IntradayDetail = "5min"  // Detail5min represents 5 minutes detail level
+	Detail15min IntradayDetail = "15min" // Detail15min represents 15 minutes detail level
+)
+
+func (d IntradayDetail) validate(allowed ...IntradayDetail) error {
+	for _, a := range allowed {
+		if d == a {
+			return nil
+		}
+	}
+	return fmt.Errorf("fitbit: unsupported detail level %q", string(d))
+}
+
+// IntradayTimeWindow represents a time window of intraday data within a day.
+//
+// Only the hour and minute of Start and End are used.
+type IntradayTimeWindow struct {
+	Start time.Time
+	End   time.Time
+}
+
+func (w *IntradayTimeWindow) validate() error {
+	start, end := w.Start.Hour()*60+w.Start.Minute(), w.End.Hour()*60+w.End.Minute()
+	if start >= end {
+		return fmt.Errorf("fitbit: start time %s must be before end time %s", w.Start.Format("15:04"), w.End.Format("15:04"))
+	}
+	return nil
+}
+
+type (
+	// IntradayOption is an option of the methods retrieving intraday data.
+	IntradayOption func(*intradayOptions)
+
+	intradayOptions struct {
+		location        *time.Location
+		profileTimezone bool
+		utc             bool
+	}
+)
+
+// WithUserTimezone makes the timestamps of intraday data located in `loc`,
+// which should be the user's timezone.
+//
+// It takes precedence over WithProfileTimezone.
+func WithUserTimezone(loc *time.Location) IntradayOption {
+	return func(o *intradayOptions) {
+		o.location = loc
+	}
+}
+
+// WithProfileTimezone makes the timestamps of intraday data located in the timezone of the user's profile.
+//
+// The profile is retrieved by GetProfile, which requires Scope.Profile and consumes the rate limit.
+// The timezone is cached on the Client per user ID, except for CurrentUserID since it differs by token,
+// so specify the user ID or use WithUserTimezone when the timezone is known.
+func WithProfileTimezone() IntradayOption {
+	return func(o *intradayOptions) {
+		o.profileTimezone = true
+	}
+}
+
+// WithUTCTimestamps makes Fitbit APIs return the intraday data of the date in UTC with `timezone=UTC` parameter,
+// so that the timestamps represent the instants in UTC instead of the user's local time.
+//
+// It takes precedence over WithUserTimezone and WithProfileTimezone.
+// Only GetActivityIntraday, GetHeartRateIntraday and GetHeartRateIntradayByInterval support it,
+// and the other methods return an error with it.
+func WithUTCTimestamps() IntradayOption {
+	return func(o *intradayOptions) {
+		o.utc = true
+	}
+}
+
+func newIntradayOptions(opts []IntradayOption) *intradayOptions {
+	var o intradayOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return &o
+}
+
+// query returns the query string to be appended to the endpoint.
+func (o *intradayOptions) query() string {
+	if o.utc {
+		return "?timezone=UTC"
+	}
+	return ""
+}
+
+// rejectUTCTimestamps returns an error when `opts` has WithUTCTimestamps, for the endpoints which do not support it.
+func rejectUTCTimestamps(opts []IntradayOption) error {
+	if newIntradayOptions(opts).utc {
+		return errors.New("fitbit: UTC timestamps are not supported by the endpoint")
+	}
+	return nil
+}
+
+// intradayLocation returns the location of the timestamps of intraday data specified by `opts`.
+// It is UTC by default, where the timestamps represent the user's local time unless WithUTCTimestamps is given.
+func (c *Client) intradayLocation(ctx context.Context, userID string, token *Token, opts []IntradayOption) (*time.Location, error) {
+	o := newIntradayOptions(opts)
+	if o.utc {
+		return time.UTC, nil
+	}
+	if o.location != nil {
+		return o.location, nil
+	}
+	if o.profileTimezone {
+		return c.profileLocation(ctx, userID, token)
+	}
+	return time.UTC, nil
+}
+
+// profileLocation returns the timezone of the user's profile, which is cached per user ID.
+func (c *Client) profileLocation(ctx context.Context, userID string, token *Token) (*time.Location, error) {
+	userID = resolveUserID(userID)
+	cacheable := userID != CurrentUserID
+	if cacheable {
+		c.profileLocationsMu.Lock()
+		loc, ok := c.profileLocations[userID]
+		c.profileLocationsMu.Unlock()
+		if ok {
+			return loc, nil
+		}
+	}
+	profile, _, _, err := c.GetProfile(ctx, userID, token)
+	if err != nil {
+		return nil, fmt.Errorf("fitbit: cannot resolve user's timezone: %w", err)
+	}
+	loc := time.UTC
+	if profile.Timezone != nil {
+		loc = profile.Timezone
+	}
+	if cacheable {
+		c.profileLocationsMu.Lock()
+		if c.profileLocations == nil {
+			c.profileLocations = make(map[string]*time.Location)
+		}
+		c.profileLocations[userID] = loc
+		c.profileLocationsMu.Unlock()
+	}
+	return loc, nil
+}
+
+type (
+	rawIntradayDataset struct {
+		Dataset []struct {
+			Time  string  `json:"time"`
+			Value float64 `json:"value"`
+		} `json:"dataset"`
+		DatasetInterval int64  `json:"datasetInterval"`
+		DatasetType     string `json:"datasetType"`
+	}
+
+	// IntradayPoint represents a value of intraday data.
+	IntradayPoint struct {
+		Time  *time.Time // in user's local time, located in UTC unless IntradayOption specifies the timezone
+		Value float64
+	}
+)
+
+// points resolves the dataset against the date in the location.
+func (d *rawIntradayDataset) points(date time.Time, loc *time.Location) ([]IntradayPoint, error) {
+	points := make([]IntradayPoint, len(d.Dataset))
+	for i, data := range d.Dataset {
+		t, err := parseTimeInLocation("2006-01-02 15:04:05", date.Format(dateFormat)+" "+data.Time, loc)
+		if err != nil {
+			return nil, err
+		}
+		points[i] = IntradayPoint{
+			Time:  t,
+			Value: data.Value,
+		}
+	}
+	return points, nil
+}
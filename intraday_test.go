@@ -0,0 +1,43 @@
+package fitbit
+
+import (
+	"context"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestIntradayLocationCachesProfileTimezone(t *testing.T) {
+	var profiles int32
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&profiles, 1)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"user":{"encodedId":"ABC123","dateOfBirth":"1990-01-01","memberSince":"2020-01-01","timezone":"Asia/Tokyo","offsetFromUTCMillis":32400000}}`))
+	}))
+	token := &Token{AccessToken: "access-token", TokenType: "Bearer", Expiry: time.Now().Add(time.Hour)}
+	opts := []IntradayOption{WithProfileTimezone()}
+
+	for i := 0; i < 3; i++ {
+		loc, err := c.intradayLocation(context.Background(), "ABC123", token, opts)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if loc.String() != "Asia/Tokyo" {
+			t.Errorf("location = %q, want %q", loc, "Asia/Tokyo")
+		}
+	}
+	if got := atomic.LoadInt32(&profiles); got != 1 {
+		t.Errorf("profile requests = %d, want 1", got)
+	}
+
+	// The profile of CurrentUserID is retrieved each time since it differs by token.
+	for i := 0; i < 2; i++ {
+		if _, err := c.intradayLocation(context.Background(), CurrentUserID, token, opts); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if got := atomic.LoadInt32(&profiles); got != 3 {
+		t.Errorf("profile requests = %d, want 3", got)
+	}
+}
@@ -1,5 +1,11 @@
 package fitbit
 
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
 // Locale is used to specify the language and units of API responses.
 type Locale string
 
@@ -14,6 +20,30 @@ const (
 	LocaleUnitedStates  Locale = "en_US"
 )
 
+// AllLocales returns all the supported locales.
+func AllLocales() []Locale {
+	return []Locale{
+		LocaleAustralia,
+		LocaleFrance,
+		LocaleGermany,
+		LocaleJapan,
+		LocaleNewZealand,
+		LocaleSpain,
+		LocaleUnitedKingdom,
+		LocaleUnitedStates,
+	}
+}
+
+// IsValid reports whether the locale is one of the supported locales.
+func (l Locale) IsValid() bool {
+	for _, locale := range AllLocales() {
+		if l == locale {
+			return true
+		}
+	}
+	return false
+}
+
 func (l *Locale) asString() string {
 	if l == nil {
 		return ""
@@ -21,6 +51,17 @@ func (l *Locale) asString() string {
 	return string(*l)
 }
 
+// acceptLocaleKey is the context key to override the locale of Accept-Locale header.
+type acceptLocaleKey struct{}
+
+// withFoodLocale returns a copy of ctx which makes the request use the locale set by SetFoodLocale, if any.
+func (c *Client) withFoodLocale(ctx context.Context) context.Context {
+	if c.foodLocale == "" {
+		return ctx
+	}
+	return context.WithValue(ctx, acceptLocaleKey{}, c.foodLocale)
+}
+
 // Unit represents a list of units used in API responses.
 type Unit struct {
 	Distance         string
@@ -30,6 +71,7 @@ type Unit struct {
 	BodyMeasurements string
 	Liquids          string
 	BloodGlucose     string
+	Temperature      string
 }
 
 var (
@@ -43,6 +85,7 @@ var (
 		BodyMeasurements: "in",
 		Liquids:          "fl oz",
 		BloodGlucose:     "mg/dL",
+		Temperature:      TemperatureFahrenheit,
 	}
 
 	// UnitedKingdomUnit represents a list of units that is used
@@ -55,6 +98,7 @@ var (
 		BodyMeasurements: "cm",
 		Liquids:          "ml",
 		BloodGlucose:     "mmol/l",
+		Temperature:      TemperatureCelsius,
 	}
 
 	// MetricUnit represents a list of units that is used
@@ -67,9 +111,17 @@ var (
 		BodyMeasurements: "cm",
 		Liquids:          "ml",
 		BloodGlucose:     "mmol/l",
+		Temperature:      TemperatureCelsius,
 	}
 )
 
+// Unit returns Unit that is used when the language is set to the locale.
+//
+// MetricUnit is returned for locales other than LocaleUnitedStates and LocaleUnitedKingdom.
+func (l Locale) Unit() *Unit {
+	return getCorrespondingUnit(l)
+}
+
 func getCorrespondingUnit(locale Locale) *Unit {
 	switch locale {
 	case LocaleUnitedStates:
@@ -80,3 +132,70 @@ func getCorrespondingUnit(locale Locale) *Unit {
 		return MetricUnit
 	}
 }
+
+// Conversion factors of the units used by Fitbit APIs into the metric ones.
+var (
+	distanceFactors = map[string]float64{"km": 1, "mile": 1.609344}
+	heightFactors   = map[string]float64{"cm": 1, "in": 2.54}
+	weightFactors   = map[string]float64{"kg": 1, "lb": 0.45359237, "st": 6.35029318}
+	liquidsFactors  = map[string]float64{"ml": 1, "fl oz": 29.5735295625}
+)
+
+// ConvertDistance converts the distance `value` from the Distance unit of `from` to the one of `to`,
+// e.g. from UnitedStatesUnit to MetricUnit.
+//
+// It returns an error when either unit is nil or not supported, which applies to the other converters as well.
+func ConvertDistance(value float64, from, to *Unit) (float64, error) {
+	if err := validateUnits(from, to); err != nil {
+		return 0, err
+	}
+	return convertUnit(value, from.Distance, to.Distance, distanceFactors)
+}
+
+// ConvertHeight converts the height `value` from the Height unit of `from` to the one of `to`.
+func ConvertHeight(value float64, from, to *Unit) (float64, error) {
+	if err := validateUnits(from, to); err != nil {
+		return 0, err
+	}
+	return convertUnit(value, from.Height, to.Height, heightFactors)
+}
+
+// ConvertWeight converts the weight `value` from the Weight unit of `from` to the one of `to`.
+func ConvertWeight(value float64, from, to *Unit) (float64, error) {
+	if err := validateUnits(from, to); err != nil {
+		return 0, err
+	}
+	return convertUnit(value, from.Weight, to.Weight, weightFactors)
+}
+
+// ConvertLiquids converts the liquid volume `value` from the Liquids unit of `from` to the one of `to`.
+//
+// "fl oz" is treated as US fluid ounces.
+func ConvertLiquids(value float64, from, to *Unit) (float64, error) {
+	if err := validateUnits(from, to); err != nil {
+		return 0, err
+	}
+	return convertUnit(value, from.Liquids, to.Liquids, liquidsFactors)
+}
+
+func validateUnits(from, to *Unit) error {
+	if from == nil || to == nil {
+		return errors.New("fitbit: unit is nil")
+	}
+	return nil
+}
+
+func convertUnit(value float64, from, to string, factors map[string]float64) (float64, error) {
+	if from == to {
+		return value, nil
+	}
+	fromFactor, ok := factors[from]
+	if !ok {
+		return 0, fmt.Errorf("fitbit: unsupported unit %q", from)
+	}
+	toFactor, ok := factors[to]
+	if !ok {
+		return 0, fmt.Errorf("fitbit: unsupported unit %q", to)
+	}
+	return value * fromFactor / toFactor, nil
+}
@@ -0,0 +1,80 @@
+package fitbit
+
+import (
+	"math"
+	"testing"
+)
+
+func TestLocaleConstantsType(t *testing.T) {
+	locales := map[string]interface{}{
+		"LocaleAustralia":     LocaleAustralia,
+		"LocaleFrance":        LocaleFrance,
+		"LocaleGermany":       LocaleGermany,
+		"LocaleJapan":         LocaleJapan,
+		"LocaleNewZealand":    LocaleNewZealand,
+		"LocaleSpain":         LocaleSpain,
+		"LocaleUnitedKingdom": LocaleUnitedKingdom,
+		"LocaleUnitedStates":  LocaleUnitedStates,
+	}
+	for name, v := range locales {
+		if _, ok := v.(Locale); !ok {
+			t.Errorf("%s has type %T, want Locale", name, v)
+		}
+	}
+	if got, want := len(AllLocales()), len(locales); got != want {
+		t.Errorf("len(AllLocales()) = %d, want %d", got, want)
+	}
+}
+
+func TestConvertUnits(t *testing.T) {
+	tests := []struct {
+		name    string
+		convert func(value float64, from, to *Unit) (float64, error)
+		value   float64
+		from    *Unit
+		to      *Unit
+		want    float64
+	}{
+		{"mile to km", ConvertDistance, 1, UnitedStatesUnit, MetricUnit, 1.609344},
+		{"km to mile", ConvertDistance, 42.195, MetricUnit, UnitedStatesUnit, 26.218757},
+		{"km to km", ConvertDistance, 5, UnitedKingdomUnit, MetricUnit, 5},
+		{"in to cm", ConvertHeight, 70, UnitedStatesUnit, MetricUnit, 177.8},
+		{"cm to in", ConvertHeight, 2.54, MetricUnit, UnitedStatesUnit, 1},
+		{"st to kg", ConvertWeight, 1, UnitedKingdomUnit, MetricUnit, 6.35029318},
+		{"kg to st", ConvertWeight, 70, MetricUnit, UnitedKingdomUnit, 11.023113},
+		{"lb to kg", ConvertWeight, 1, UnitedStatesUnit, MetricUnit, 0.45359237},
+		{"st to lb", ConvertWeight, 1, UnitedKingdomUnit, UnitedStatesUnit, 14},
+		{"fl oz to ml", ConvertLiquids, 8, UnitedStatesUnit, MetricUnit, 236.588236},
+		{"ml to fl oz", ConvertLiquids, 1000, MetricUnit, UnitedStatesUnit, 33.814023},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.convert(tt.value, tt.from, tt.to)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if math.Abs(got-tt.want) > 1e-6 {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConvertUnitsError(t *testing.T) {
+	tests := []struct {
+		name string
+		from *Unit
+		to   *Unit
+	}{
+		{"nil from", nil, MetricUnit},
+		{"nil to", MetricUnit, nil},
+		{"unsupported unit", &Unit{Weight: "oz"}, MetricUnit},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ConvertWeight(1, tt.from, tt.to); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
@@ -0,0 +1,165 @@
+package fitbit
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+type (
+	// MealFood represents a food in a meal.
+	MealFood struct {
+		FoodID int64   `json:"foodId"`
+		Amount float64 `json:"amount"`
+		UnitID int64   `json:"unitId"`
+	}
+
+	// Meal represents a user's meal, which is a set of foods to be logged at once.
+	Meal struct {
+		ID          int64      `json:"id"`
+		Name        string     `json:"name"`
+		Description string     `json:"description"`
+		MealFoods   []MealFood `json:"mealFoods"`
+	}
+
+	// MealParams represents parameters to create or update a meal.
+	//
+	// Name and at least one of MealFoods are required.
+	MealParams struct {
+		Name        string     `json:"name"`
+		Description string     `json:"description"`
+		MealFoods   []MealFood `json:"mealFoods"`
+	}
+
+	rawMealsResponse struct {
+		Meals []Meal `json:"meals"`
+	}
+
+	rawMealResponse struct {
+		Meal *Meal `json:"meal"`
+	}
+)
+
+func (p *MealParams) validate() error {
+	if p.Name == "" {
+		return errors.New("fitbit: Name must be set")
+	}
+	if len(p.MealFoods) == 0 {
+		return errors.New("fitbit: at least one of MealFoods must be set")
+	}
+	for i, food := range p.MealFoods {
+		if err := validateFoodID(food.FoodID); err != nil {
+			return err
+		}
+		if food.UnitID <= 0 {
+			return fmt.Errorf("fitbit: UnitID of MealFoods[%d] must be set", i)
+		}
+		if food.Amount <= 0 {
+			return fmt.Errorf("fitbit: Amount of MealFoods[%d] must be positive", i)
+		}
+	}
+	return nil
+}
+
+// GetMeals retrieves a list of the user's meals.
+//
+// Scope.Nutrition is required.
+//
+// Web API Reference: https://dev.fitbit.com/build/reference/web-api/nutrition/get-meals/
+func (c *Client) GetMeals(ctx context.Context, userID string, token *Token) ([]Meal, *RateLimit, []byte, error) {
+	endpoint := c.getEndpoint("GetMeals", resolveUserID(userID))
+	b, rateLimit, err := c.getRequest(ctx, token, endpoint)
+	if err != nil {
+		return nil, nil, b, err
+	}
+	var resp rawMealsResponse
+	if err := json.Unmarshal(b, &resp); err != nil {
+		return nil, rateLimit, b, err
+	}
+	if resp.Meals == nil {
+		resp.Meals = []Meal{}
+	}
+	return resp.Meals, rateLimit, b, nil
+}
+
+// GetMeal retrieves the user's meal.
+//
+// Scope.Nutrition is required.
+//
+// Web API Reference: https://dev.fitbit.com/build/reference/web-api/nutrition/get-meal/
+func (c *Client) GetMeal(ctx context.Context, userID string, mealID int64, token *Token) (*Meal, *RateLimit, []byte, error) {
+	if err := validateMealID(mealID); err != nil {
+		return nil, nil, nil, err
+	}
+	endpoint := c.getEndpoint("GetMeal", resolveUserID(userID), mealID)
+	b, rateLimit, err := c.getRequest(ctx, token, endpoint)
+	if err != nil {
+		return nil, nil, b, err
+	}
+	var resp rawMealResponse
+	if err := json.Unmarshal(b, &resp); err != nil {
+		return nil, rateLimit, b, err
+	}
+	return resp.Meal, rateLimit, b, nil
+}
+
+// CreateMeal creates a meal, and returns the created meal with its assigned meal ID.
+//
+// Scope.Nutrition is required.
+//
+// Web API Reference: https://dev.fitbit.com/build/reference/web-api/nutrition/create-meal/
+func (c *Client) CreateMeal(ctx context.Context, userID string, params MealParams, token *Token) (*Meal, *RateLimit, []byte, error) {
+	if err := params.validate(); err != nil {
+		return nil, nil, nil, err
+	}
+	endpoint := c.getEndpoint("CreateMeal", resolveUserID(userID))
+	return c.postMeal(ctx, token, endpoint, &params)
+}
+
+// UpdateMeal updates the user's meal.
+//
+// All the foods in the meal are replaced with MealFoods.
+//
+// Scope.Nutrition is required.
+//
+// Web API Reference: https://dev.fitbit.com/build/reference/web-api/nutrition/update-meal/
+func (c *Client) UpdateMeal(ctx context.Context, userID string, mealID int64, params MealParams, token *Token) (*Meal, *RateLimit, []byte, error) {
+	if err := validateMealID(mealID); err != nil {
+		return nil, nil, nil, err
+	}
+	if err := params.validate(); err != nil {
+		return nil, nil, nil, err
+	}
+	endpoint := c.getEndpoint("UpdateMeal", resolveUserID(userID), mealID)
+	return c.postMeal(ctx, token, endpoint, &params)
+}
+
+func (c *Client) postMeal(ctx context.Context, token *Token, endpoint endpointURL, params *MealParams) (*Meal, *RateLimit, []byte, error) {
+	b, rateLimit, err := c.postJSONRequest(ctx, token, endpoint, params)
+	if err != nil {
+		return nil, nil, b, err
+	}
+	var resp rawMealResponse
+	if err := json.Unmarshal(b, &resp); err != nil {
+		return nil, rateLimit, b, err
+	}
+	return resp.Meal, rateLimit, b, nil
+}
+
+// DeleteMeal deletes the user's meal.
+//
+// Scope.Nutrition is required.
+//
+// Web API Reference: https://dev.fitbit.com/build/reference/web-api/nutrition/delete-meal/
+func (c *Client) DeleteMeal(ctx context.Context, userID string, mealID int64, token *Token) (*RateLimit, error) {
+	if err := validateMealID(mealID); err != nil {
+		return nil, err
+	}
+	endpoint := c.getEndpoint("DeleteMeal", resolveUserID(userID), mealID)
+	_, rateLimit, err := c.deleteRequest(ctx, token, endpoint)
+	if err != nil {
+		return nil, err
+	}
+	return rateLimit, nil
+}
@@ -0,0 +1,108 @@
+package fitbit
+
+import (
+	"strings"
+	"sync"
+	"time"
+)
+
+var (
+	endpointTemplatesOnce sync.Once
+	endpointTemplates     map[string]string
+)
+
+// SetMetricsFunc sets the function to be invoked on every completed request,
+// with the templated endpoint, the status code and the elapsed time including token refresh and retries.
+//
+// The endpoint is templated like "/1/user/{user-id}/activities/date/{date}.json"
+// so that the cardinality of metrics labels stays bounded.
+// Requests to endpoints which are not implemented by this package, like the ones sent by `Do`, are reported as "other".
+// The status code is 0 when no response is received.
+// It is not set by default.
+func (c *Client) SetMetricsFunc(f func(endpoint string, statusCode int, dur time.Duration)) {
+	c.metricsFunc = f
+}
+
+// SetCacheMetricsFunc sets the function to be invoked on every response to a request which is cacheable by the cache set by `SetCache`,
+// with the endpoint templated in the same way as SetMetricsFunc and whether the cached body is used.
+//
+// A hit means that Fitbit APIs have responded with 304 Not Modified to the cached ETag,
+// and a miss means the other responses, including the ones to requests without a cached ETag.
+// It is not set by default.
+func (c *Client) SetCacheMetricsFunc(f func(endpoint string, hit bool)) {
+	c.cacheMetricsFunc = f
+}
+
+// templateEndpoint returns the template of the endpoint labeled `label` in apiEndpoints,
+// or "other" for an unknown label.
+func templateEndpoint(label string) string {
+	endpointTemplatesOnce.Do(compileEndpointTemplates)
+	if template, ok := endpointTemplates[label]; ok {
+		return template
+	}
+	return "other"
+}
+
+func compileEndpointTemplates() {
+	endpointTemplates = make(map[string]string, len(apiEndpoints))
+	for label, endpoint := range apiEndpoints {
+		if i := strings.Index(endpoint, "?"); i >= 0 {
+			endpoint = endpoint[:i]
+		}
+
+		var (
+			template strings.Builder
+			prev     string
+		)
+		for rest := endpoint; rest != ""; {
+			i := strings.Index(rest, "%")
+			if i < 0 {
+				i = len(rest)
+			}
+			literal := rest[:i]
+			template.WriteString(literal)
+			prev += literal
+			if i == len(rest) {
+				break
+			}
+			verb := rest[i : i+2]
+			rest = rest[i+2:]
+			if verb == "%d" {
+				template.WriteString("{id}")
+			} else {
+				template.WriteString(templateParamName(prev))
+			}
+			prev += verb
+		}
+		endpointTemplates[label] = template.String()
+	}
+}
+
+// templateParamName names the `%s` parameter which follows `prefix` of an endpoint.
+func templateParamName(prefix string) string {
+	switch {
+	case strings.HasSuffix(prefix, "/user/"):
+		return "{user-id}"
+	case strings.HasSuffix(prefix, "/user/%s/"):
+		return "{collection-path}"
+	case strings.HasSuffix(prefix, "/activities/"), strings.HasSuffix(prefix, "/body/"):
+		return "{resource}"
+	case strings.HasSuffix(prefix, "/goals/"):
+		return "{period}"
+	case strings.HasSuffix(prefix, "apiSubscriptions/"):
+		return "{subscription-id}"
+	case strings.HasSuffix(prefix, "/date/"):
+		return "{date}"
+	case strings.HasSuffix(prefix, "/date/%s/"):
+		return "{end-date-or-period}"
+	case strings.HasSuffix(prefix, "/tracker/"):
+		return "{tracker-id}"
+	case strings.HasSuffix(prefix, "/time/"):
+		return "{start-time}"
+	case strings.HasSuffix(prefix, "/time/%s/"):
+		return "{end-time}"
+	case strings.HasSuffix(prefix, "/1d/"):
+		return "{detail-level}"
+	}
+	return "{param}"
+}
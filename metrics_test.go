@@ -0,0 +1,52 @@
+package fitbit
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestTemplateEndpoint(t *testing.T) {
+	tests := []struct {
+		label string
+		want  string
+	}{
+		{"GetProfile", "/1/user/{user-id}/profile.json"},
+		{"GetActivityIntraday", "/1/user/{user-id}/activities/{resource}/date/{date}/1d/{detail-level}.json"},
+		{"DeleteAlarm", "/1/user/{user-id}/devices/tracker/{tracker-id}/alarms/{id}.json"},
+		{"", "other"},
+		{"UnknownEndpoint", "other"},
+	}
+	for _, tt := range tests {
+		if got := templateEndpoint(tt.label); got != tt.want {
+			t.Errorf("templateEndpoint(%q) = %q, want %q", tt.label, got, tt.want)
+		}
+	}
+}
+
+func TestMetricsFunc(t *testing.T) {
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{}`))
+	}))
+	var endpoints []string
+	c.SetMetricsFunc(func(endpoint string, statusCode int, dur time.Duration) {
+		if statusCode != http.StatusOK {
+			t.Errorf("statusCode = %d, want %d", statusCode, http.StatusOK)
+		}
+		endpoints = append(endpoints, endpoint)
+	})
+	token := &Token{AccessToken: "access-token", TokenType: "Bearer", Expiry: time.Now().Add(time.Hour)}
+
+	if _, _, err := c.getRequest(context.Background(), token, c.getEndpoint("GetProfile", "ABC123")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := c.Do(context.Background(), token, http.MethodGet, "/1/user/ABC123/unsupported.json", nil, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []string{"/1/user/{user-id}/profile.json", "other"}
+	if len(endpoints) != len(want) || endpoints[0] != want[0] || endpoints[1] != want[1] {
+		t.Errorf("endpoints = %q, want %q", endpoints, want)
+	}
+}
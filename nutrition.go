@@ -3,9 +3,73 @@ package fitbit
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
 	"time"
 )
 
+const (
+	// MaxConcurrentNutritionRequests is the maximum number of requests issued at the same time
+	// by GetFoodLogsByDateRange and GetWaterLogsByDateRange.
+	MaxConcurrentNutritionRequests = 4
+
+	// MaxNutritionDateRange is the maximum number of days which can be retrieved at once
+	// by GetFoodLogsByDateRange and GetWaterLogsByDateRange.
+	// Each day issues a request, so it bounds the rate limit consumed by a call.
+	MaxNutritionDateRange = 31
+)
+
+// MealType represents the meal type of food logs.
+type MealType int64
+
+const (
+	MealTypeBreakfast      MealType = 1 // MealTypeBreakfast represents breakfast
+	MealTypeMorningSnack   MealType = 2 // MealTypeMorningSnack represents morning snack
+	MealTypeLunch          MealType = 3 // MealTypeLunch represents lunch
+	MealTypeAfternoonSnack MealType = 4 // MealTypeAfternoonSnack represents afternoon snack
+	MealTypeDinner         MealType = 5 // MealTypeDinner represents dinner
+	MealTypeAnytime        MealType = 7 // MealTypeAnytime represents anytime
+)
+
+func (mt MealType) validate() error {
+	switch mt {
+	case MealTypeBreakfast, MealTypeMorningSnack, MealTypeLunch, MealTypeAfternoonSnack, MealTypeDinner, MealTypeAnytime:
+		return nil
+	}
+	return fmt.Errorf("fitbit: invalid meal type %d", int64(mt))
+}
+
+// FoodPlanIntensity represents the intensity of a food plan.
+type FoodPlanIntensity string
+
+const (
+	FoodPlanIntensityMaintenance FoodPlanIntensity = "MAINTENANCE"
+	FoodPlanIntensityEasier      FoodPlanIntensity = "EASIER"
+	FoodPlanIntensityMedium      FoodPlanIntensity = "MEDIUM"
+	FoodPlanIntensityKindaHard   FoodPlanIntensity = "KINDAHARD"
+	FoodPlanIntensityHarder      FoodPlanIntensity = "HARDER"
+)
+
+func (i FoodPlanIntensity) validate() error {
+	switch i {
+	case FoodPlanIntensityMaintenance, FoodPlanIntensityEasier, FoodPlanIntensityMedium, FoodPlanIntensityKindaHard, FoodPlanIntensityHarder:
+		return nil
+	}
+	return fmt.Errorf("fitbit: invalid food plan intensity %q", string(i))
+}
+
+// WaterUnit represents the unit of water.
+type WaterUnit string
+
+const (
+	WaterUnitMilliliter  WaterUnit = "ml"
+	WaterUnitFluidOunce  WaterUnit = "fl oz"
+	WaterUnitCup         WaterUnit = "cup"
+	WaterUnitUnspecified WaterUnit = "" // WaterUnitUnspecified means to use the liquids unit of the language setting
+)
+
 type (
 	// WaterLog represents a user's water log.
 	WaterLog struct {
@@ -25,8 +89,230 @@ type (
 		Total float64
 		Logs  []WaterLog
 	}
+
+	rawWaterLogResponse struct {
+		WaterLog *WaterLog `json:"waterLog"`
+	}
+
+	rawWaterGoal struct {
+		Goal struct {
+			Goal      float64 `json:"goal"`
+			StartDate string  `json:"startDate"`
+		} `json:"goal"`
+	}
+
+	// WaterGoal represents a user's daily water consumption goal.
+	WaterGoal struct {
+		Goal      float64
+		Unit      string // Unit is derived from the language setting
+		StartDate *time.Time
+	}
+
+	// FoodUnit represents a unit of food measurement.
+	FoodUnit struct {
+		ID     int64  `json:"id"`
+		Name   string `json:"name"`
+		Plural string `json:"plural"`
+	}
+
+	// Food represents a food in the food database.
+	//
+	// Amount, Unit and MealTypeID are only set for frequent and recent foods,
+	// and represent how the food was logged.
+	Food struct {
+		AccessLevel        string    `json:"accessLevel"`
+		Brand              string    `json:"brand"`
+		Calories           int64     `json:"calories"`
+		DefaultServingSize float64   `json:"defaultServingSize"`
+		DefaultUnit        *FoodUnit `json:"defaultUnit"`
+		FoodID             int64     `json:"foodId"`
+		IsGeneric          bool      `json:"isGeneric"`
+		Locale             string    `json:"locale"`
+		Name               string    `json:"name"`
+		Units              []int64   `json:"units"`
+		Amount             float64   `json:"amount"`
+		Unit               *FoodUnit `json:"unit"`
+		MealTypeID         MealType  `json:"mealTypeId"`
+	}
+
+	rawSearchFoodsResponse struct {
+		Foods []Food `json:"foods"`
+	}
+
+	// LoggedFood represents a food in a food log entry.
+	LoggedFood struct {
+		AccessLevel string    `json:"accessLevel"`
+		Amount      float64   `json:"amount"`
+		Brand       string    `json:"brand"`
+		Calories    int64     `json:"calories"`
+		FoodID      int64     `json:"foodId"`
+		Locale      string    `json:"locale"`
+		MealTypeID  MealType  `json:"mealTypeId"`
+		Name        string    `json:"name"`
+		Unit        *FoodUnit `json:"unit"`
+		Units       []int64   `json:"units"`
+	}
+
+	// NutritionalValues represents nutritional values of food.
+	NutritionalValues struct {
+		Calories float64 `json:"calories"`
+		Carbs    float64 `json:"carbs"`
+		Fat      float64 `json:"fat"`
+		Fiber    float64 `json:"fiber"`
+		Protein  float64 `json:"protein"`
+		Sodium   float64 `json:"sodium"`
+	}
+
+	rawFoodLog struct {
+		IsFavorite        bool               `json:"isFavorite"`
+		LogDate           string             `json:"logDate"`
+		LogID             int64              `json:"logId"`
+		LoggedFood        *LoggedFood        `json:"loggedFood"`
+		NutritionalValues *NutritionalValues `json:"nutritionalValues"`
+	}
+
+	// FoodLog represents a user's food log entry.
+	FoodLog struct {
+		IsFavorite        bool
+		LogDate           *time.Time
+		LogID             int64
+		LoggedFood        *LoggedFood
+		NutritionalValues *NutritionalValues
+	}
+
+	// FoodLogGoals represents a user's daily food goals.
+	FoodLogGoals struct {
+		Calories int64 `json:"calories"`
+	}
+
+	rawFoodPlan struct {
+		EstimateDate string            `json:"estimateDate"`
+		Intensity    FoodPlanIntensity `json:"intensity"`
+		Personalized bool              `json:"personalized"`
+	}
+
+	// FoodPlan represents a user's food plan.
+	//
+	// EstimatedDate is the date when the weight goal is estimated to be reached, and nil when it is not available.
+	FoodPlan struct {
+		Intensity     FoodPlanIntensity
+		Personalized  bool
+		EstimatedDate *time.Time
+	}
+
+	// FoodGoals represents a user's food goals and the food plan if it is active.
+	FoodGoals struct {
+		Goals    *FoodLogGoals `json:"goals"`
+		FoodPlan *FoodPlan     `json:"foodPlan"`
+	}
+
+	// FoodGoalsUpdate represents parameters to update a user's food goals.
+	//
+	// Exactly one of Calories and Intensity must be set.
+	// Personalized is only used with Intensity.
+	FoodGoalsUpdate struct {
+		Calories     int64
+		Intensity    FoodPlanIntensity
+		Personalized bool
+	}
+
+	// CreateFoodRequest represents parameters to create a custom food.
+	//
+	// Name, DefaultUnitID, DefaultServingSize and Calories are required.
+	// Nutritional values are optional, and only non-nil fields are sent.
+	CreateFoodRequest struct {
+		Name               string
+		DefaultUnitID      int64
+		DefaultServingSize float64
+		Calories           int64
+		FormType           string // LIQUID or DRY
+		Description        string
+		CaloriesFromFat    *float64
+		TotalFat           *float64 // in g
+		TransFat           *float64 // in g
+		SaturatedFat       *float64 // in g
+		Cholesterol        *float64 // in mg
+		Sodium             *float64 // in mg
+		Potassium          *float64 // in mg
+		TotalCarbohydrate  *float64 // in g
+		DietaryFiber       *float64 // in g
+		Sugars             *float64 // in g
+		Protein            *float64 // in g
+	}
+
+	rawFoodResponse struct {
+		Food *Food `json:"food"`
+	}
+
+	// FoodSummary represents the daily totals of a user's food log entries.
+	//
+	// Water is in the liquids unit of the language setting.
+	FoodSummary struct {
+		Calories float64 `json:"calories"`
+		Carbs    float64 `json:"carbs"`
+		Fat      float64 `json:"fat"`
+		Fiber    float64 `json:"fiber"`
+		Protein  float64 `json:"protein"`
+		Sodium   float64 `json:"sodium"`
+		Water    float64 `json:"water"`
+	}
+
+	// FoodLogs represents a list of a user's food log entries and their daily totals.
+	FoodLogs struct {
+		Logs    []FoodLog     `json:"foods"`
+		Summary *FoodSummary  `json:"summary"`
+		Goals   *FoodLogGoals `json:"goals"`
+	}
+
+	rawFoodLogResponse struct {
+		FoodLog *FoodLog `json:"foodLog"`
+	}
+
+	// FoodLogRequest represents parameters to create a food log entry.
+	//
+	// Either FoodID or FoodName must be set.
+	// When FoodName is set, Calories is required.
+	FoodLogRequest struct {
+		FoodID    int64
+		FoodName  string
+		BrandName string // only used with FoodName
+		Calories  int64  // only used with FoodName
+		MealType  MealType
+		UnitID    int64
+		Amount    float64
+		Date      time.Time
+		Favorite  bool // only used with FoodID
+	}
+
+	// FoodLogUpdate represents parameters to update a food log entry.
+	//
+	// UnitID and Amount are used for foods,
+	// and Calories is used for custom foods which were logged with FoodName.
+	FoodLogUpdate struct {
+		MealType MealType
+		UnitID   int64
+		Amount   float64
+		Calories int64
+	}
 )
 
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (g *WaterGoal) UnmarshalJSON(b []byte) error {
+	var raw rawWaterGoal
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return err
+	}
+
+	startDate, err := parseTime(dateFormat, raw.Goal.StartDate)
+	if err != nil {
+		return err
+	}
+
+	g.Goal = raw.Goal.Goal
+	g.StartDate = startDate
+	return nil
+}
+
 // UnmarshalJSON implements the json.Unmarshaler interface.
 func (w *Water) UnmarshalJSON(b []byte) error {
 	var raw rawWater
@@ -45,7 +331,7 @@ func (w *Water) UnmarshalJSON(b []byte) error {
 //
 // Web API Reference: https://dev.fitbit.com/build/reference/web-api/nutrition/get-water-log/
 func (c *Client) GetWater(ctx context.Context, userID string, date time.Time, token *Token) (*Water, *RateLimit, []byte, error) {
-	endpoint := c.getEndpoint("GetWater", userID, date.Format(dateFormat))
+	endpoint := c.getEndpoint("GetWater", resolveUserID(userID), date.Format(dateFormat))
 	b, rateLimit, err := c.getRequest(ctx, token, endpoint)
 	if err != nil {
 		return nil, nil, b, err
@@ -56,3 +342,554 @@ func (c *Client) GetWater(ctx context.Context, userID string, date time.Time, to
 	}
 	return &water, rateLimit, b, nil
 }
+
+func (c *Client) waterUnitOrDefault(unit WaterUnit) WaterUnit {
+	if unit == WaterUnitUnspecified {
+		return WaterUnit(c.GetUnit().Liquids)
+	}
+	return unit
+}
+
+// LogWater creates a water log entry.
+//
+// When `unit` is WaterUnitUnspecified, the liquids unit of the language setting is used.
+//
+// Scope.Nutrition is required.
+//
+// Web API Reference: https://dev.fitbit.com/build/reference/web-api/nutrition/create-water-log/
+func (c *Client) LogWater(ctx context.Context, userID string, amount float64, unit WaterUnit, date time.Time, token *Token) (*WaterLog, *RateLimit, []byte, error) {
+	if amount <= 0 {
+		return nil, nil, nil, errors.New("fitbit: amount must be positive")
+	}
+	endpoint := c.getEndpoint("LogWater", resolveUserID(userID))
+	values := url.Values{}
+	values.Set("amount", formatFloat(amount))
+	values.Set("date", date.Format(dateFormat))
+	values.Set("unit", string(c.waterUnitOrDefault(unit)))
+	return c.postWaterLog(ctx, token, endpoint, values)
+}
+
+// UpdateWaterLog updates a water log entry.
+//
+// When `unit` is WaterUnitUnspecified, the liquids unit of the language setting is used.
+//
+// Scope.Nutrition is required.
+//
+// Web API Reference: https://dev.fitbit.com/build/reference/web-api/nutrition/update-water-log/
+func (c *Client) UpdateWaterLog(ctx context.Context, userID string, logID int64, amount float64, unit WaterUnit, token *Token) (*WaterLog, *RateLimit, []byte, error) {
+	if err := validateLogID(logID); err != nil {
+		return nil, nil, nil, err
+	}
+	if amount <= 0 {
+		return nil, nil, nil, errors.New("fitbit: amount must be positive")
+	}
+	endpoint := c.getEndpoint("UpdateWaterLog", resolveUserID(userID), logID)
+	values := url.Values{}
+	values.Set("amount", formatFloat(amount))
+	values.Set("unit", string(c.waterUnitOrDefault(unit)))
+	return c.postWaterLog(ctx, token, endpoint, values)
+}
+
+func (c *Client) postWaterLog(ctx context.Context, token *Token, endpoint endpointURL, values url.Values) (*WaterLog, *RateLimit, []byte, error) {
+	b, rateLimit, err := c.postRequest(ctx, token, endpoint, values)
+	if err != nil {
+		return nil, nil, b, err
+	}
+	var resp rawWaterLogResponse
+	if err := json.Unmarshal(b, &resp); err != nil {
+		return nil, rateLimit, b, err
+	}
+	return resp.WaterLog, rateLimit, b, nil
+}
+
+// DeleteWaterLog deletes a water log entry.
+//
+// Scope.Nutrition is required.
+//
+// Web API Reference: https://dev.fitbit.com/build/reference/web-api/nutrition/delete-water-log/
+func (c *Client) DeleteWaterLog(ctx context.Context, userID string, logID int64, token *Token) (*RateLimit, error) {
+	if err := validateLogID(logID); err != nil {
+		return nil, err
+	}
+	endpoint := c.getEndpoint("DeleteWaterLog", resolveUserID(userID), logID)
+	_, rateLimit, err := c.deleteRequest(ctx, token, endpoint)
+	if err != nil {
+		return nil, err
+	}
+	return rateLimit, nil
+}
+
+// GetWaterGoal retrieves the user's daily water consumption goal.
+//
+// The goal is in the liquids unit of the language setting.
+//
+// Scope.Nutrition is required.
+//
+// Web API Reference: https://dev.fitbit.com/build/reference/web-api/nutrition/get-water-goal/
+func (c *Client) GetWaterGoal(ctx context.Context, userID string, token *Token) (*WaterGoal, *RateLimit, []byte, error) {
+	endpoint := c.getEndpoint("GetWaterGoal", resolveUserID(userID))
+	b, rateLimit, err := c.getRequest(ctx, token, endpoint)
+	if err != nil {
+		return nil, nil, b, err
+	}
+	return c.parseWaterGoal(b, rateLimit)
+}
+
+// UpdateWaterGoal updates the user's daily water consumption goal.
+//
+// `target` is in the liquids unit of the language setting.
+//
+// Scope.Nutrition is required.
+//
+// Web API Reference: https://dev.fitbit.com/build/reference/web-api/nutrition/create-water-goal/
+func (c *Client) UpdateWaterGoal(ctx context.Context, userID string, target float64, token *Token) (*WaterGoal, *RateLimit, []byte, error) {
+	if target <= 0 {
+		return nil, nil, nil, errors.New("fitbit: target must be positive")
+	}
+	endpoint := c.getEndpoint("UpdateWaterGoal", resolveUserID(userID))
+	values := url.Values{}
+	values.Set("target", formatFloat(target))
+	b, rateLimit, err := c.postRequest(ctx, token, endpoint, values)
+	if err != nil {
+		return nil, nil, b, err
+	}
+	return c.parseWaterGoal(b, rateLimit)
+}
+
+func (c *Client) parseWaterGoal(b []byte, rateLimit *RateLimit) (*WaterGoal, *RateLimit, []byte, error) {
+	var goal WaterGoal
+	if err := json.Unmarshal(b, &goal); err != nil {
+		return nil, rateLimit, b, err
+	}
+	goal.Unit = c.GetUnit().Liquids
+	return &goal, rateLimit, b, nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (p *FoodPlan) UnmarshalJSON(b []byte) error {
+	var raw rawFoodPlan
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return err
+	}
+
+	estimatedDate, err := parseTime(dateFormat, raw.EstimateDate)
+	if err != nil {
+		return err
+	}
+
+	p.Intensity = raw.Intensity
+	p.Personalized = raw.Personalized
+	p.EstimatedDate = estimatedDate
+	return nil
+}
+
+// EffectiveCalories returns the daily calorie target in `goals.calories`.
+//
+// Fitbit returns it in both shapes of the response: it is the explicit goal without a food plan,
+// and the one Fitbit calculates from the food plan with it. The food plan itself has no calorie target,
+// so it returns 0 when `goals` is absent, even if the food plan is present.
+func (g *FoodGoals) EffectiveCalories() int64 {
+	if g == nil || g.Goals == nil {
+		return 0
+	}
+	return g.Goals.Calories
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (l *FoodLog) UnmarshalJSON(b []byte) error {
+	var raw rawFoodLog
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return err
+	}
+
+	logDate, err := parseTime(dateFormat, raw.LogDate)
+	if err != nil {
+		return err
+	}
+
+	l.IsFavorite = raw.IsFavorite
+	l.LogDate = logDate
+	l.LogID = raw.LogID
+	l.LoggedFood = raw.LoggedFood
+	l.NutritionalValues = raw.NutritionalValues
+	return nil
+}
+
+func (r *FoodLogRequest) values() (url.Values, error) {
+	if (r.FoodID > 0) == (r.FoodName != "") {
+		return nil, errors.New("fitbit: exactly one of FoodID and FoodName must be set")
+	}
+	if err := r.MealType.validate(); err != nil {
+		return nil, err
+	}
+	if r.UnitID <= 0 {
+		return nil, errors.New("fitbit: UnitID must be set")
+	}
+	if r.Amount <= 0 {
+		return nil, errors.New("fitbit: Amount must be positive")
+	}
+	if r.Date.IsZero() {
+		return nil, errors.New("fitbit: Date must be set")
+	}
+	values := url.Values{}
+	if r.FoodID > 0 {
+		values.Set("foodId", strconv.FormatInt(r.FoodID, 10))
+		if r.Favorite {
+			values.Set("favorite", "true")
+		}
+	} else {
+		if r.Calories <= 0 {
+			return nil, errors.New("fitbit: Calories must be positive when FoodName is set")
+		}
+		values.Set("foodName", r.FoodName)
+		values.Set("calories", strconv.FormatInt(r.Calories, 10))
+		if r.BrandName != "" {
+			values.Set("brandName", r.BrandName)
+		}
+	}
+	values.Set("mealTypeId", strconv.FormatInt(int64(r.MealType), 10))
+	values.Set("unitId", strconv.FormatInt(r.UnitID, 10))
+	values.Set("amount", formatFloat(r.Amount))
+	values.Set("date", r.Date.Format(dateFormat))
+	return values, nil
+}
+
+func (u *FoodLogUpdate) values() (url.Values, error) {
+	if err := u.MealType.validate(); err != nil {
+		return nil, err
+	}
+	values := url.Values{}
+	values.Set("mealTypeId", strconv.FormatInt(int64(u.MealType), 10))
+	if u.UnitID > 0 {
+		values.Set("unitId", strconv.FormatInt(u.UnitID, 10))
+	}
+	if u.Amount > 0 {
+		values.Set("amount", formatFloat(u.Amount))
+	}
+	if u.Calories > 0 {
+		values.Set("calories", strconv.FormatInt(u.Calories, 10))
+	}
+	return values, nil
+}
+
+func (u *FoodGoalsUpdate) values() (url.Values, error) {
+	if (u.Calories > 0) == (u.Intensity != "") {
+		return nil, errors.New("fitbit: exactly one of Calories and Intensity must be set")
+	}
+	values := url.Values{}
+	if u.Calories > 0 {
+		values.Set("calories", strconv.FormatInt(u.Calories, 10))
+		return values, nil
+	}
+	if err := u.Intensity.validate(); err != nil {
+		return nil, err
+	}
+	values.Set("intensity", string(u.Intensity))
+	values.Set("personalized", strconv.FormatBool(u.Personalized))
+	return values, nil
+}
+
+func (r *CreateFoodRequest) values() (url.Values, error) {
+	if r.Name == "" {
+		return nil, errors.New("fitbit: Name must be set")
+	}
+	if r.DefaultUnitID <= 0 {
+		return nil, errors.New("fitbit: DefaultUnitID must be set")
+	}
+	if r.DefaultServingSize <= 0 {
+		return nil, errors.New("fitbit: DefaultServingSize must be positive")
+	}
+	if r.Calories <= 0 {
+		return nil, errors.New("fitbit: Calories must be positive")
+	}
+	values := url.Values{}
+	values.Set("name", r.Name)
+	values.Set("defaultFoodMeasurementUnitId", strconv.FormatInt(r.DefaultUnitID, 10))
+	values.Set("defaultServingSize", formatFloat(r.DefaultServingSize))
+	values.Set("calories", strconv.FormatInt(r.Calories, 10))
+	if r.FormType != "" {
+		values.Set("formType", r.FormType)
+	}
+	if r.Description != "" {
+		values.Set("description", r.Description)
+	}
+	for key, value := range map[string]*float64{
+		"caloriesFromFat":   r.CaloriesFromFat,
+		"totalFat":          r.TotalFat,
+		"transFat":          r.TransFat,
+		"saturatedFat":      r.SaturatedFat,
+		"cholesterol":       r.Cholesterol,
+		"sodium":            r.Sodium,
+		"potassium":         r.Potassium,
+		"totalCarbohydrate": r.TotalCarbohydrate,
+		"dietaryFiber":      r.DietaryFiber,
+		"sugars":            r.Sugars,
+		"protein":           r.Protein,
+	} {
+		if value != nil {
+			values.Set(key, formatFloat(*value))
+		}
+	}
+	return values, nil
+}
+
+// GetFoodLogs retrieves a list of a user's food log entries and their totals for a given day.
+//
+// Scope.Nutrition is required.
+//
+// Web API Reference: https://dev.fitbit.com/build/reference/web-api/nutrition/get-food-log/
+func (c *Client) GetFoodLogs(ctx context.Context, userID string, date time.Time, token *Token) (*FoodLogs, *RateLimit, []byte, error) {
+	endpoint := c.getEndpoint("GetFoodLogs", resolveUserID(userID), date.Format(dateFormat))
+	b, rateLimit, err := c.getRequest(c.withFoodLocale(ctx), token, endpoint)
+	if err != nil {
+		return nil, nil, b, err
+	}
+	var foodLogs FoodLogs
+	if err := json.Unmarshal(b, &foodLogs); err != nil {
+		return nil, rateLimit, b, err
+	}
+	return &foodLogs, rateLimit, b, nil
+}
+
+// LogFood creates a food log entry.
+//
+// Scope.Nutrition is required.
+//
+// Web API Reference: https://dev.fitbit.com/build/reference/web-api/nutrition/create-food-log/
+func (c *Client) LogFood(ctx context.Context, userID string, params FoodLogRequest, token *Token) (*FoodLog, *RateLimit, []byte, error) {
+	values, err := params.values()
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	endpoint := c.getEndpoint("LogFood", resolveUserID(userID))
+	return c.postFoodLog(ctx, token, endpoint, values)
+}
+
+// UpdateFoodLog updates a food log entry.
+//
+// Scope.Nutrition is required.
+//
+// Web API Reference: https://dev.fitbit.com/build/reference/web-api/nutrition/update-food-log/
+func (c *Client) UpdateFoodLog(ctx context.Context, userID string, logID int64, params FoodLogUpdate, token *Token) (*FoodLog, *RateLimit, []byte, error) {
+	if err := validateLogID(logID); err != nil {
+		return nil, nil, nil, err
+	}
+	values, err := params.values()
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	endpoint := c.getEndpoint("UpdateFoodLog", resolveUserID(userID), logID)
+	return c.postFoodLog(ctx, token, endpoint, values)
+}
+
+func (c *Client) postFoodLog(ctx context.Context, token *Token, endpoint endpointURL, values url.Values) (*FoodLog, *RateLimit, []byte, error) {
+	b, rateLimit, err := c.postRequest(c.withFoodLocale(ctx), token, endpoint, values)
+	if err != nil {
+		return nil, nil, b, err
+	}
+	var resp rawFoodLogResponse
+	if err := json.Unmarshal(b, &resp); err != nil {
+		return nil, rateLimit, b, err
+	}
+	return resp.FoodLog, rateLimit, b, nil
+}
+
+// DeleteFoodLog deletes a food log entry.
+//
+// Scope.Nutrition is required.
+//
+// Web API Reference: https://dev.fitbit.com/build/reference/web-api/nutrition/delete-food-log/
+func (c *Client) DeleteFoodLog(ctx context.Context, userID string, logID int64, token *Token) (*RateLimit, error) {
+	if err := validateLogID(logID); err != nil {
+		return nil, err
+	}
+	endpoint := c.getEndpoint("DeleteFoodLog", resolveUserID(userID), logID)
+	_, rateLimit, err := c.deleteRequest(ctx, token, endpoint)
+	if err != nil {
+		return nil, err
+	}
+	return rateLimit, nil
+}
+
+// SearchFoods retrieves a list of foods in the food database that match the query.
+//
+// The food database is chosen by the locale set by `SetFoodLocale`, or `SetLocale` when it is not set.
+// An empty slice is returned when there are no matches.
+//
+// Scope.Nutrition is required.
+//
+// Web API Reference: https://dev.fitbit.com/build/reference/web-api/nutrition/search-foods/
+func (c *Client) SearchFoods(ctx context.Context, query string, token *Token) ([]Food, *RateLimit, []byte, error) {
+	endpoint := c.getEndpoint("SearchFoods", url.QueryEscape(query))
+	b, rateLimit, err := c.getRequest(c.withFoodLocale(ctx), token, endpoint)
+	if err != nil {
+		return nil, nil, b, err
+	}
+	var resp rawSearchFoodsResponse
+	if err := json.Unmarshal(b, &resp); err != nil {
+		return nil, rateLimit, b, err
+	}
+	if resp.Foods == nil {
+		resp.Foods = []Food{}
+	}
+	return resp.Foods, rateLimit, b, nil
+}
+
+// GetFoodUnits retrieves a list of all valid food units.
+//
+// The IDs of units are used to log foods.
+//
+// Web API Reference: https://dev.fitbit.com/build/reference/web-api/nutrition/get-food-units/
+func (c *Client) GetFoodUnits(ctx context.Context, token *Token) ([]FoodUnit, *RateLimit, []byte, error) {
+	endpoint := c.getEndpoint("GetFoodUnits")
+	b, rateLimit, err := c.getRequest(c.withFoodLocale(ctx), token, endpoint)
+	if err != nil {
+		return nil, nil, b, err
+	}
+	foodUnits := []FoodUnit{}
+	if err := json.Unmarshal(b, &foodUnits); err != nil {
+		return nil, rateLimit, b, err
+	}
+	return foodUnits, rateLimit, b, nil
+}
+
+// GetFoodGoals retrieves the user's daily calorie consumption goal and the food plan if it is active.
+//
+// Scope.Nutrition is required.
+//
+// Web API Reference: https://dev.fitbit.com/build/reference/web-api/nutrition/get-food-goals/
+func (c *Client) GetFoodGoals(ctx context.Context, userID string, token *Token) (*FoodGoals, *RateLimit, []byte, error) {
+	endpoint := c.getEndpoint("GetFoodGoals", resolveUserID(userID))
+	b, rateLimit, err := c.getRequest(ctx, token, endpoint)
+	if err != nil {
+		return nil, nil, b, err
+	}
+	var goals FoodGoals
+	if err := json.Unmarshal(b, &goals); err != nil {
+		return nil, rateLimit, b, err
+	}
+	return &goals, rateLimit, b, nil
+}
+
+// UpdateFoodGoals updates the user's daily calorie consumption goal or food plan.
+//
+// Scope.Nutrition is required.
+//
+// Web API Reference: https://dev.fitbit.com/build/reference/web-api/nutrition/create-food-goal/
+func (c *Client) UpdateFoodGoals(ctx context.Context, userID string, params FoodGoalsUpdate, token *Token) (*FoodGoals, *RateLimit, []byte, error) {
+	values, err := params.values()
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	endpoint := c.getEndpoint("UpdateFoodGoals", resolveUserID(userID))
+	b, rateLimit, err := c.postRequest(ctx, token, endpoint, values)
+	if err != nil {
+		return nil, nil, b, err
+	}
+	var goals FoodGoals
+	if err := json.Unmarshal(b, &goals); err != nil {
+		return nil, rateLimit, b, err
+	}
+	return &goals, rateLimit, b, nil
+}
+
+// GetFavoriteFoods retrieves a list of the user's favorite foods.
+//
+// Scope.Nutrition is required.
+//
+// Web API Reference: https://dev.fitbit.com/build/reference/web-api/nutrition/get-favorite-foods/
+func (c *Client) GetFavoriteFoods(ctx context.Context, userID string, token *Token) ([]Food, *RateLimit, []byte, error) {
+	endpoint := c.getEndpoint("GetFavoriteFoods", resolveUserID(userID))
+	return c.getFoods(ctx, token, endpoint)
+}
+
+// GetFrequentFoods retrieves a list of the user's frequent foods.
+//
+// Scope.Nutrition is required.
+//
+// Web API Reference: https://dev.fitbit.com/build/reference/web-api/nutrition/get-frequent-foods/
+func (c *Client) GetFrequentFoods(ctx context.Context, userID string, token *Token) ([]Food, *RateLimit, []byte, error) {
+	endpoint := c.getEndpoint("GetFrequentFoods", resolveUserID(userID))
+	return c.getFoods(ctx, token, endpoint)
+}
+
+// GetRecentFoods retrieves a list of the user's recent foods.
+//
+// Scope.Nutrition is required.
+//
+// Web API Reference: https://dev.fitbit.com/build/reference/web-api/nutrition/get-recent-foods/
+func (c *Client) GetRecentFoods(ctx context.Context, userID string, token *Token) ([]Food, *RateLimit, []byte, error) {
+	endpoint := c.getEndpoint("GetRecentFoods", resolveUserID(userID))
+	return c.getFoods(ctx, token, endpoint)
+}
+
+func (c *Client) getFoods(ctx context.Context, token *Token, endpoint endpointURL) ([]Food, *RateLimit, []byte, error) {
+	b, rateLimit, err := c.getRequest(c.withFoodLocale(ctx), token, endpoint)
+	if err != nil {
+		return nil, nil, b, err
+	}
+	var foods []Food
+	if err := json.Unmarshal(b, &foods); err != nil {
+		return nil, rateLimit, b, err
+	}
+	if foods == nil {
+		foods = []Food{}
+	}
+	return foods, rateLimit, b, nil
+}
+
+// AddFavoriteFood adds the food to the user's favorite foods.
+//
+// Scope.Nutrition is required.
+//
+// Web API Reference: https://dev.fitbit.com/build/reference/web-api/nutrition/add-favorite-foods/
+func (c *Client) AddFavoriteFood(ctx context.Context, userID string, foodID int64, token *Token) (*RateLimit, error) {
+	if err := validateFoodID(foodID); err != nil {
+		return nil, err
+	}
+	endpoint := c.getEndpoint("AddFavoriteFood", resolveUserID(userID), foodID)
+	_, rateLimit, err := c.postRequest(ctx, token, endpoint, url.Values{})
+	if err != nil {
+		return nil, err
+	}
+	return rateLimit, nil
+}
+
+// DeleteFavoriteFood removes the food from the user's favorite foods.
+//
+// Scope.Nutrition is required.
+//
+// Web API Reference: https://dev.fitbit.com/build/reference/web-api/nutrition/delete-favorite-foods/
+func (c *Client) DeleteFavoriteFood(ctx context.Context, userID string, foodID int64, token *Token) (*RateLimit, error) {
+	if err := validateFoodID(foodID); err != nil {
+		return nil, err
+	}
+	endpoint := c.getEndpoint("DeleteFavoriteFood", resolveUserID(userID), foodID)
+	_, rateLimit, err := c.deleteRequest(ctx, token, endpoint)
+	if err != nil {
+		return nil, err
+	}
+	return rateLimit, nil
+}
+
+// CreateFood creates a custom food, and returns the created food with its assigned food ID.
+//
+// Scope.Nutrition is required.
+//
+// Web API Reference: https://dev.fitbit.com/build/reference/web-api/nutrition/create-food/
+func (c *Client) CreateFood(ctx context.Context, userID string, params CreateFoodRequest, token *Token) (*Food, *RateLimit, []byte, error) {
+	values, err := params.values()
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	endpoint := c.getEndpoint("CreateFood", resolveUserID(userID))
+	b, rateLimit, err := c.postRequest(c.withFoodLocale(ctx), token, endpoint, values)
+	if err != nil {
+		return nil, nil, b, err
+	}
+	var resp rawFoodResponse
+	if err := json.Unmarshal(b, &resp); err != nil {
+		return nil, rateLimit, b, err
+	}
+	return resp.Food, rateLimit, b, nil
+}
@@ -0,0 +1,90 @@
+package fitbit
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestFoodGoalsUnmarshalJSON(t *testing.T) {
+	estimatedDate := time.Date(2024, 5, 25, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name         string
+		body         string
+		wantCalories int64
+		wantPlan     *FoodPlan
+	}{
+		{
+			name:         "explicit calorie goal",
+			body:         `{"goals":{"calories":2100}}`,
+			wantCalories: 2100,
+		},
+		{
+			name:         "food plan",
+			body:         `{"foodPlan":{"estimateDate":"2024-05-25","intensity":"MEDIUM","personalized":true},"goals":{"calories":1800}}`,
+			wantCalories: 1800,
+			wantPlan: &FoodPlan{
+				Intensity:     FoodPlanIntensityMedium,
+				Personalized:  true,
+				EstimatedDate: &estimatedDate,
+			},
+		},
+		{
+			name:         "food plan without goals",
+			body:         `{"foodPlan":{"estimateDate":"2024-05-25","intensity":"MEDIUM","personalized":true}}`,
+			wantCalories: 0,
+			wantPlan: &FoodPlan{
+				Intensity:     FoodPlanIntensityMedium,
+				Personalized:  true,
+				EstimatedDate: &estimatedDate,
+			},
+		},
+		{
+			name:         "no goals",
+			body:         `{}`,
+			wantCalories: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var goals FoodGoals
+			if err := json.Unmarshal([]byte(tt.body), &goals); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := goals.EffectiveCalories(); got != tt.wantCalories {
+				t.Errorf("EffectiveCalories() = %d, want %d", got, tt.wantCalories)
+			}
+			plan := goals.FoodPlan
+			switch {
+			case tt.wantPlan == nil && plan != nil:
+				t.Errorf("FoodPlan = %+v, want nil", plan)
+			case tt.wantPlan != nil && plan == nil:
+				t.Errorf("FoodPlan = nil, want %+v", tt.wantPlan)
+			case tt.wantPlan != nil:
+				if plan.Intensity != tt.wantPlan.Intensity || plan.Personalized != tt.wantPlan.Personalized {
+					t.Errorf("FoodPlan = %+v, want %+v", plan, tt.wantPlan)
+				}
+				if plan.EstimatedDate == nil || !plan.EstimatedDate.Equal(*tt.wantPlan.EstimatedDate) {
+					t.Errorf("EstimatedDate = %v, want %v", plan.EstimatedDate, tt.wantPlan.EstimatedDate)
+				}
+			}
+		})
+	}
+}
+
+func TestFoodLogsByDateRangeExceedsMax(t *testing.T) {
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request to %s", r.URL.Path)
+	}))
+	start := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(0, 0, MaxNutritionDateRange)
+	if _, err := c.GetFoodLogsByDateRange(context.Background(), "ABC123", start, end, &Token{}); !errors.Is(err, ErrInvalidDateRange) {
+		t.Errorf("GetFoodLogsByDateRange() error = %v, want ErrInvalidDateRange", err)
+	}
+	if _, err := c.GetWaterLogsByDateRange(context.Background(), "ABC123", start, end, &Token{}); !errors.Is(err, ErrInvalidDateRange) {
+		t.Errorf("GetWaterLogsByDateRange() error = %v, want ErrInvalidDateRange", err)
+	}
+}
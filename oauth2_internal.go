@@ -50,6 +50,9 @@ func contextClient(ctx context.Context) *http.Client {
 		if hc, ok := ctx.Value(HTTPClient).(*http.Client); ok {
 			return hc
 		}
+		if hc, ok := ctx.Value(oauth2.HTTPClient).(*http.Client); ok {
+			return hc
+		}
 	}
 	return http.DefaultClient
 }
@@ -59,6 +62,7 @@ type tokenJSON struct {
 	TokenType    string `json:"token_type"`
 	RefreshToken string `json:"refresh_token"`
 	ExpiresIn    int64  `json:"expires_in"`
+	UserID       string `json:"user_id"`
 }
 
 func (e *tokenJSON) expiry() (t time.Time) {
@@ -68,18 +72,19 @@ func (e *tokenJSON) expiry() (t time.Time) {
 	return
 }
 
-func doTokenRoundTrip(ctx context.Context, req *http.Request) (*Token, error) {
+// doTokenRoundTrip sends the token request, and returns the token and the user ID that Fitbit returns with it.
+func doTokenRoundTrip(ctx context.Context, req *http.Request) (*Token, string, error) {
 	r, err := ctxhttp.Do(ctx, contextClient(ctx), req)
 	if err != nil {
-		return nil, err
+		return nil, "", err
 	}
 	body, err := ioutil.ReadAll(io.LimitReader(r.Body, 1<<20))
 	r.Body.Close()
 	if err != nil {
-		return nil, fmt.Errorf("fitbit(oauth2): cannot fetch token: %v", err)
+		return nil, "", fmt.Errorf("fitbit(oauth2): cannot fetch token: %v", err)
 	}
 	if code := r.StatusCode; code < 200 || code > 299 {
-		return nil, &oauth2.RetrieveError{
+		return nil, "", &oauth2.RetrieveError{
 			Response: r,
 			Body:     body,
 		}
@@ -88,20 +93,20 @@ func doTokenRoundTrip(ctx context.Context, req *http.Request) (*Token, error) {
 	// It is expected that the response's 'content-Type' is `application/json`
 	var tj tokenJSON
 	if err = json.Unmarshal(body, &tj); err != nil {
-		return nil, err
+		return nil, "", err
 	}
 	return &Token{
 		AccessToken:  tj.AccessToken,
 		TokenType:    tj.TokenType,
 		RefreshToken: tj.RefreshToken,
 		Expiry:       tj.expiry(),
-	}, nil
+	}, tj.UserID, nil
 }
 
-func retrieveToken(ctx context.Context, clientID, clientSecret, tokenURL string, v url.Values, appType ApplicationType) (*Token, error) {
+func retrieveToken(ctx context.Context, clientID, clientSecret, tokenURL string, v url.Values, appType ApplicationType) (*Token, string, error) {
 	req, err := newTokenRequest(tokenURL, clientID, clientSecret, v, appType)
 	if err != nil {
-		return nil, err
+		return nil, "", err
 	}
 	return doTokenRoundTrip(ctx, req)
 }
@@ -0,0 +1,153 @@
+package fitbit
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrNoMorePages is returned by FollowPagination when there is no page to follow.
+var ErrNoMorePages = errors.New("fitbit: no more pages")
+
+// SortOrder represents the sort order of list endpoints.
+type SortOrder string
+
+const (
+	SortAscending  SortOrder = "asc"
+	SortDescending SortOrder = "desc"
+)
+
+type (
+	rawPagination struct {
+		BeforeDate string    `json:"beforeDate"`
+		AfterDate  string    `json:"afterDate"`
+		Limit      int64     `json:"limit"`
+		Offset     int64     `json:"offset"`
+		Sort       SortOrder `json:"sort"`
+		Next       string    `json:"next"`
+		Previous   string    `json:"previous"`
+	}
+
+	// Pagination represents the pagination of list endpoints.
+	//
+	// Next and Previous are the URLs of the next and previous pages, empty if there is no such page.
+	Pagination struct {
+		BeforeDate *time.Time
+		AfterDate  *time.Time
+		Limit      int64
+		Offset     int64
+		Sort       SortOrder
+		Next       string
+		Previous   string
+	}
+)
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (p *Pagination) UnmarshalJSON(b []byte) error {
+	var raw rawPagination
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return err
+	}
+
+	beforeDate, err := parsePaginationDate(raw.BeforeDate)
+	if err != nil {
+		return err
+	}
+	afterDate, err := parsePaginationDate(raw.AfterDate)
+	if err != nil {
+		return err
+	}
+
+	p.BeforeDate = beforeDate
+	p.AfterDate = afterDate
+	p.Limit = raw.Limit
+	p.Offset = raw.Offset
+	p.Sort = raw.Sort
+	p.Next = raw.Next
+	p.Previous = raw.Previous
+	return nil
+}
+
+// parsePaginationDate parses a date of the pagination, which may or may not have the time part.
+func parsePaginationDate(value string) (*time.Time, error) {
+	if len(value) > len(dateFormat) {
+		return parseTime("2006-01-02T15:04:05", value)
+	}
+	return parseTime(dateFormat, value)
+}
+
+// HasNext reports whether there is the next page.
+func (p *Pagination) HasNext() bool {
+	return p != nil && p.Next != ""
+}
+
+// HasPrevious reports whether there is the previous page.
+func (p *Pagination) HasPrevious() bool {
+	return p != nil && p.Previous != ""
+}
+
+// FollowPagination retrieves the page at `next`, which is Pagination.Next or Pagination.Previous
+// of a list endpoint response, and decodes the response into `out`.
+//
+// `out` should be a pointer to the same type as the original response, like *ECGLogList.
+// ErrNoMorePages is returned when `next` is empty.
+// Only URLs of Fitbit APIs are followed so that the token is not sent to other hosts.
+func (c *Client) FollowPagination(ctx context.Context, next string, out interface{}, token *Token) (*RateLimit, []byte, error) {
+	if next == "" {
+		return nil, nil, ErrNoMorePages
+	}
+	if !strings.HasPrefix(next, c.baseURL+"/") {
+		return nil, nil, fmt.Errorf("fitbit: cannot follow pagination to %q", next)
+	}
+	b, rateLimit, err := c.getRequest(ctx, token, endpointURL{url: next})
+	if err != nil {
+		return nil, b, err
+	}
+	if err := json.Unmarshal(b, out); err != nil {
+		return rateLimit, b, err
+	}
+	return rateLimit, b, nil
+}
+
+// listQuery builds the query parameters shared by list endpoints.
+//
+// Either of `beforeDate` or `afterDate` is required. When `sort` is empty,
+// it defaults to SortDescending for `beforeDate` and SortAscending for `afterDate`.
+func listQuery(beforeDate, afterDate *time.Time, sort SortOrder, limit, offset, maxLimit int) (url.Values, error) {
+	if (beforeDate == nil) == (afterDate == nil) {
+		return nil, errors.New("fitbit: either of beforeDate or afterDate is required")
+	}
+	if limit < 1 || limit > maxLimit {
+		return nil, fmt.Errorf("fitbit: limit must be between 1 and %d", maxLimit)
+	}
+	if offset < 0 {
+		return nil, errors.New("fitbit: offset must not be negative")
+	}
+	query := url.Values{}
+	if beforeDate != nil {
+		if sort == "" {
+			sort = SortDescending
+		}
+		if sort != SortDescending {
+			return nil, errors.New("fitbit: sort must be desc when beforeDate is specified")
+		}
+		query.Set("beforeDate", beforeDate.Format(dateFormat))
+	} else {
+		if sort == "" {
+			sort = SortAscending
+		}
+		if sort != SortAscending {
+			return nil, errors.New("fitbit: sort must be asc when afterDate is specified")
+		}
+		query.Set("afterDate", afterDate.Format(dateFormat))
+	}
+	query.Set("sort", string(sort))
+	query.Set("limit", strconv.Itoa(limit))
+	query.Set("offset", strconv.Itoa(offset))
+	return query, nil
+}
@@ -0,0 +1,7 @@
+package fitbit
+
+import "strconv"
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
@@ -2,42 +2,52 @@ package fitbit
 
 import (
 	crand "crypto/rand"
+	"errors"
+	"fmt"
 	"math/big"
-	mrand "math/rand"
-	"time"
-
-	"github.com/anyappinc/fitbit/logger"
+	"strings"
 )
 
-var (
-	pseudoRand    *mrand.Rand
-	RandomByteSet = NumberLetters + UppercaseAlphabetLetters + LowercaseAlphabetLetters // RandomByteSet is a set of characters used to construct random bytes
-)
+// RandomByteSet is a set of characters used to construct random bytes.
+//
+// All of them are unreserved characters defined by RFC 3986, so that the bytes can be used as `code_verifier` of PKCE.
+// When it is replaced, it must consist of unique unreserved characters, otherwise generating random bytes fails.
+var RandomByteSet = NumberLetters + UppercaseAlphabetLetters + LowercaseAlphabetLetters
+
+const unreservedCharacters = NumberLetters + UppercaseAlphabetLetters + LowercaseAlphabetLetters + "-._~"
 
-func init() {
-	pseudoRand = mrand.New(mrand.NewSource(time.Now().UnixNano()))
+// validateRandomByteSet reports an error unless `set` consists of unique unreserved characters.
+func validateRandomByteSet(set string) error {
+	if set == "" {
+		return errors.New("fitbit: RandomByteSet is empty")
+	}
+	for i := 0; i < len(set); i++ {
+		if strings.IndexByte(unreservedCharacters, set[i]) < 0 {
+			return fmt.Errorf("fitbit: RandomByteSet contains a reserved character %q", set[i])
+		}
+		if strings.IndexByte(set[:i], set[i]) >= 0 {
+			return fmt.Errorf("fitbit: RandomByteSet contains a duplicate character %q", set[i])
+		}
+	}
+	return nil
 }
 
-func randomBytes(length uint64) []byte {
+// randomBytes returns cryptographically secure random bytes consisting of RandomByteSet.
+func randomBytes(length uint64) ([]byte, error) {
+	set := RandomByteSet
+	if err := validateRandomByteSet(set); err != nil {
+		return nil, err
+	}
 	var (
-		cryptoRandErr               error
-		randomByteSetLength         = len(RandomByteSet)
-		randomByteSetLengthAsBitInt = big.NewInt(int64(randomByteSetLength))
+		randomByteSetLengthAsBitInt = big.NewInt(int64(len(set)))
 		randBytes                   = make([]byte, length)
 	)
 	for i := range randBytes {
-		if cryptoRandErr == nil {
-			idx, err := crand.Int(crand.Reader, randomByteSetLengthAsBitInt)
-			if err == nil {
-				randBytes[i] = RandomByteSet[idx.Int64()]
-			} else {
-				logger.Warn.Printf("Can not generate secure random number: %v", err)
-				cryptoRandErr = err
-			}
-		}
-		if cryptoRandErr != nil {
-			randBytes[i] = RandomByteSet[pseudoRand.Intn(randomByteSetLength)]
+		idx, err := crand.Int(crand.Reader, randomByteSetLengthAsBitInt)
+		if err != nil {
+			return nil, fmt.Errorf("fitbit: cannot generate secure random number: %w", err)
 		}
+		randBytes[i] = set[idx.Int64()]
 	}
-	return randBytes
+	return randBytes, nil
 }
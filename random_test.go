@@ -0,0 +1,58 @@
+package fitbit
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRandomBytesUniqueness(t *testing.T) {
+	const n = 1000
+	seen := make(map[string]bool, n)
+	for i := 0; i < n; i++ {
+		b, err := randomBytes(CodeVerifierLength)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if uint64(len(b)) != CodeVerifierLength {
+			t.Fatalf("len = %d, want %d", len(b), CodeVerifierLength)
+		}
+		if seen[string(b)] {
+			t.Fatalf("duplicate random bytes %q", b)
+		}
+		seen[string(b)] = true
+	}
+}
+
+func TestRandomBytesDistribution(t *testing.T) {
+	const perCharacter = 1000
+	b, err := randomBytes(uint64(len(RandomByteSet) * perCharacter))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	counts := make(map[byte]int, len(RandomByteSet))
+	for _, c := range b {
+		if strings.IndexByte(RandomByteSet, c) < 0 {
+			t.Fatalf("character %q is not in RandomByteSet", c)
+		}
+		counts[c]++
+	}
+	// The standard deviation of each count is about 31, so the bounds are never exceeded in practice.
+	for i := 0; i < len(RandomByteSet); i++ {
+		c := RandomByteSet[i]
+		if got := counts[c]; got < perCharacter*3/4 || got > perCharacter*5/4 {
+			t.Errorf("character %q appeared %d times, want about %d", c, got, perCharacter)
+		}
+	}
+}
+
+func TestRandomBytesInvalidSet(t *testing.T) {
+	original := RandomByteSet
+	defer func() { RandomByteSet = original }()
+
+	for _, set := range []string{"", "abc+/", "aab"} {
+		RandomByteSet = set
+		if _, err := randomBytes(16); err == nil {
+			t.Errorf("randomBytes() with RandomByteSet %q succeeded, want an error", set)
+		}
+	}
+}
@@ -0,0 +1,82 @@
+package fitbit
+
+import (
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// retryTransport is a http.RoundTripper that retries a request
+// when Fitbit APIs respond with 429 Too Many Requests.
+type retryTransport struct {
+	base       http.RoundTripper
+	maxRetries int
+	maxWait    time.Duration
+}
+
+// RoundTrip implements the http.RoundTripper interface.
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	for attempt := 0; ; attempt++ {
+		r := req
+		if attempt > 0 {
+			r = req.Clone(req.Context())
+			if req.Body != nil && req.Body != http.NoBody {
+				body, err := req.GetBody()
+				if err != nil {
+					return nil, err
+				}
+				r.Body = body
+			}
+		}
+		resp, err := t.base.RoundTrip(r)
+		if err != nil || resp.StatusCode != http.StatusTooManyRequests || attempt >= t.maxRetries {
+			return resp, err
+		}
+		if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+			// the request can not be sent again
+			return resp, nil
+		}
+
+		wait := retryAfter(resp.Header, time.Now())
+		if wait > t.maxWait {
+			// retrying earlier would just consume the rate limit
+			return resp, nil
+		}
+		io.Copy(ioutil.Discard, resp.Body)
+		resp.Body.Close()
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, req.Context().Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// retryAfter returns the duration to wait before retrying.
+//
+// Retry-After header is used if it is available, which is either seconds or HTTP-date,
+// otherwise Fitbit-Rate-Limit-Reset header is used.
+func retryAfter(h http.Header, now time.Time) time.Duration {
+	if v := h.Get("Retry-After"); v != "" {
+		if seconds, err := strconv.ParseInt(v, 10, 64); err == nil {
+			return time.Duration(seconds) * time.Second
+		}
+		if t, err := http.ParseTime(v); err == nil {
+			if d := t.Sub(now); d > 0 {
+				return d
+			}
+			return 0
+		}
+	}
+	if v := h.Get("Fitbit-Rate-Limit-Reset"); v != "" {
+		if seconds, err := strconv.ParseInt(v, 10, 64); err == nil {
+			return time.Duration(seconds) * time.Second
+		}
+	}
+	return 0
+}
@@ -0,0 +1,86 @@
+package fitbit
+
+import (
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRetryAfter(t *testing.T) {
+	now := time.Date(2022, 1, 2, 3, 4, 5, 0, time.UTC)
+	tests := []struct {
+		name   string
+		header http.Header
+		want   time.Duration
+	}{
+		{"seconds", http.Header{"Retry-After": {"120"}}, 120 * time.Second},
+		{"HTTP-date", http.Header{"Retry-After": {now.Add(90 * time.Second).Format(http.TimeFormat)}}, 90 * time.Second},
+		{"past HTTP-date", http.Header{"Retry-After": {now.Add(-time.Minute).Format(http.TimeFormat)}}, 0},
+		{"Fitbit-Rate-Limit-Reset", http.Header{"Fitbit-Rate-Limit-Reset": {"600"}}, 600 * time.Second},
+		{"Retry-After precedes Fitbit-Rate-Limit-Reset", http.Header{"Retry-After": {"30"}, "Fitbit-Rate-Limit-Reset": {"600"}}, 30 * time.Second},
+		{"none", http.Header{}, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := retryAfter(tt.header, now); got != tt.want {
+				t.Errorf("retryAfter() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// roundTripFunc is a http.RoundTripper which calls itself.
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestRetryTransport(t *testing.T) {
+	tests := []struct {
+		name         string
+		header       http.Header
+		maxWait      time.Duration
+		wantAttempts int
+		wantStatus   int
+	}{
+		{"wait within maxWait", http.Header{"Retry-After": {"0"}}, time.Second, 2, http.StatusOK},
+		{"Retry-After exceeds maxWait", http.Header{"Retry-After": {"60"}}, time.Second, 1, http.StatusTooManyRequests},
+		{"Fitbit-Rate-Limit-Reset exceeds maxWait", http.Header{"Fitbit-Rate-Limit-Reset": {"600"}}, time.Second, 1, http.StatusTooManyRequests},
+		{"zero maxWait", http.Header{"Fitbit-Rate-Limit-Reset": {"600"}}, 0, 1, http.StatusTooManyRequests},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			attempts := 0
+			transport := &retryTransport{
+				base: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+					attempts++
+					status, header := http.StatusOK, http.Header{}
+					if attempts == 1 {
+						status, header = http.StatusTooManyRequests, tt.header
+					}
+					return &http.Response{StatusCode: status, Header: header, Body: ioutil.NopCloser(strings.NewReader("{}"))}, nil
+				}),
+				maxRetries: 3,
+				maxWait:    tt.maxWait,
+			}
+			req, err := http.NewRequest(http.MethodGet, "https://api.fitbit.com/1/user/-/profile.json", nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			resp, err := transport.RoundTrip(req)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			resp.Body.Close()
+			if resp.StatusCode != tt.wantStatus {
+				t.Errorf("StatusCode = %d, want %d", resp.StatusCode, tt.wantStatus)
+			}
+			if attempts != tt.wantAttempts {
+				t.Errorf("attempts = %d, want %d", attempts, tt.wantAttempts)
+			}
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package fitbit
 
 import (
+	"fmt"
 	"strings"
 )
 
@@ -43,15 +44,20 @@ func convertToScopeType(s string) ScopeType {
 
 // Scope represents the scope of permission.
 type Scope struct {
-	Activity  bool
-	Heartrate bool
-	Location  bool
-	Nutrition bool
-	Profile   bool
-	Settings  bool
-	Sleep     bool
-	Social    bool
-	Weight    bool
+	Activity          bool
+	Heartrate         bool
+	Location          bool
+	Nutrition         bool
+	Profile           bool
+	Settings          bool
+	Sleep             bool
+	Social            bool
+	Weight            bool
+	OxygenSaturation  bool
+	RespiratoryRate   bool
+	Temperature       bool
+	CardioFitness     bool
+	Electrocardiogram bool
 }
 
 func newScope(raw []string) *Scope {
@@ -76,6 +82,16 @@ func newScope(raw []string) *Scope {
 			scope.Social = true
 		case "weight":
 			scope.Weight = true
+		case "oxygen_saturation":
+			scope.OxygenSaturation = true
+		case "respiratory_rate":
+			scope.RespiratoryRate = true
+		case "temperature":
+			scope.Temperature = true
+		case "cardio_fitness":
+			scope.CardioFitness = true
+		case "electrocardiogram":
+			scope.Electrocardiogram = true
 		}
 	}
 	return scope
@@ -96,7 +112,10 @@ func parseScopeFromTokenState(scopeString string) (*Scope, ScopeType) {
 }
 
 func (s *Scope) convert() []string {
-	scopes := make([]string, 0, 9)
+	if s == nil {
+		return []string{}
+	}
+	scopes := make([]string, 0, 14)
 	if s.Activity {
 		scopes = append(scopes, "activity")
 	}
@@ -124,12 +143,27 @@ func (s *Scope) convert() []string {
 	if s.Weight {
 		scopes = append(scopes, "weight")
 	}
+	if s.OxygenSaturation {
+		scopes = append(scopes, "oxygen_saturation")
+	}
+	if s.RespiratoryRate {
+		scopes = append(scopes, "respiratory_rate")
+	}
+	if s.Temperature {
+		scopes = append(scopes, "temperature")
+	}
+	if s.CardioFitness {
+		scopes = append(scopes, "cardio_fitness")
+	}
+	if s.Electrocardiogram {
+		scopes = append(scopes, "electrocardiogram")
+	}
 	return scopes
 }
 
 // Missing returns a list of missing scope as a string slice.
 func (s *Scope) Missing(expected *Scope) []string {
-	missingScopes := make([]string, 0, 9)
+	missingScopes := make([]string, 0, 14)
 	if expected.Activity && !s.Activity {
 		missingScopes = append(missingScopes, "activity")
 	}
@@ -157,5 +191,174 @@ func (s *Scope) Missing(expected *Scope) []string {
 	if expected.Weight && !s.Weight {
 		missingScopes = append(missingScopes, "weight")
 	}
+	if expected.OxygenSaturation && !s.OxygenSaturation {
+		missingScopes = append(missingScopes, "oxygen_saturation")
+	}
+	if expected.RespiratoryRate && !s.RespiratoryRate {
+		missingScopes = append(missingScopes, "respiratory_rate")
+	}
+	if expected.Temperature && !s.Temperature {
+		missingScopes = append(missingScopes, "temperature")
+	}
+	if expected.CardioFitness && !s.CardioFitness {
+		missingScopes = append(missingScopes, "cardio_fitness")
+	}
+	if expected.Electrocardiogram && !s.Electrocardiogram {
+		missingScopes = append(missingScopes, "electrocardiogram")
+	}
 	return missingScopes
 }
+
+// Has reports whether the scope contains the specified scope.
+//
+// The scope name is matched case-insensitively against Fitbit's scope names,
+// like "activity" or "heartrate".
+func (s *Scope) Has(scope string) bool {
+	if s == nil {
+		return false
+	}
+	switch strings.ToLower(scope) {
+	case "activity":
+		return s.Activity
+	case "heartrate":
+		return s.Heartrate
+	case "location":
+		return s.Location
+	case "nutrition":
+		return s.Nutrition
+	case "profile":
+		return s.Profile
+	case "settings":
+		return s.Settings
+	case "sleep":
+		return s.Sleep
+	case "social":
+		return s.Social
+	case "weight":
+		return s.Weight
+	case "oxygen_saturation":
+		return s.OxygenSaturation
+	case "respiratory_rate":
+		return s.RespiratoryRate
+	case "temperature":
+		return s.Temperature
+	case "cardio_fitness":
+		return s.CardioFitness
+	case "electrocardiogram":
+		return s.Electrocardiogram
+	}
+	return false
+}
+
+// List returns a list of the scope as a string slice, which is empty for a nil scope.
+func (s *Scope) List() []string {
+	return s.convert()
+}
+
+// Contains reports whether the scope contains all of the other scope.
+//
+// It returns false for a nil scope. A nil `other` means no scope, which any non-nil scope contains.
+func (s *Scope) Contains(other *Scope) bool {
+	if s == nil {
+		return false
+	}
+	if other == nil {
+		return true
+	}
+	return len(s.Missing(other)) == 0
+}
+
+// ScopeName represents the name of a scope defined by Fitbit.
+type ScopeName string
+
+const (
+	ScopeActivity          ScopeName = "activity"
+	ScopeHeartRate         ScopeName = "heartrate"
+	ScopeLocation          ScopeName = "location"
+	ScopeNutrition         ScopeName = "nutrition"
+	ScopeProfile           ScopeName = "profile"
+	ScopeSettings          ScopeName = "settings"
+	ScopeSleep             ScopeName = "sleep"
+	ScopeSocial            ScopeName = "social"
+	ScopeWeight            ScopeName = "weight"
+	ScopeOxygenSaturation  ScopeName = "oxygen_saturation"
+	ScopeRespiratoryRate   ScopeName = "respiratory_rate"
+	ScopeTemperature       ScopeName = "temperature"
+	ScopeCardioFitness     ScopeName = "cardio_fitness"
+	ScopeElectrocardiogram ScopeName = "electrocardiogram"
+)
+
+// ScopeSet represents a set of scopes built from the scope names.
+type ScopeSet []ScopeName
+
+// NewScopeSet returns a ScopeSet of the scope names.
+//
+// It returns an error when `names` contains an unknown or a duplicate name.
+func NewScopeSet(names ...ScopeName) (ScopeSet, error) {
+	seen := make(map[ScopeName]bool, len(names))
+	for _, name := range names {
+		if !(&Scope{}).set(name) {
+			return nil, fmt.Errorf("fitbit: unknown scope %q", string(name))
+		}
+		if seen[name] {
+			return nil, fmt.Errorf("fitbit: duplicate scope %q", string(name))
+		}
+		seen[name] = true
+	}
+	return append(ScopeSet{}, names...), nil
+}
+
+// String returns the scope names joined with spaces, which is the format of the scope parameter of OAuth2.
+func (ss ScopeSet) String() string {
+	names := make([]string, len(ss))
+	for i, name := range ss {
+		names[i] = string(name)
+	}
+	return strings.Join(names, " ")
+}
+
+// Scope returns the Scope of the set, which can be given to NewClient.
+func (ss ScopeSet) Scope() *Scope {
+	scope := &Scope{}
+	for _, name := range ss {
+		scope.set(name)
+	}
+	return scope
+}
+
+// set enables the scope of `name`, and reports whether `name` is known.
+func (s *Scope) set(name ScopeName) bool {
+	switch name {
+	case ScopeActivity:
+		s.Activity = true
+	case ScopeHeartRate:
+		s.Heartrate = true
+	case ScopeLocation:
+		s.Location = true
+	case ScopeNutrition:
+		s.Nutrition = true
+	case ScopeProfile:
+		s.Profile = true
+	case ScopeSettings:
+		s.Settings = true
+	case ScopeSleep:
+		s.Sleep = true
+	case ScopeSocial:
+		s.Social = true
+	case ScopeWeight:
+		s.Weight = true
+	case ScopeOxygenSaturation:
+		s.OxygenSaturation = true
+	case ScopeRespiratoryRate:
+		s.RespiratoryRate = true
+	case ScopeTemperature:
+		s.Temperature = true
+	case ScopeCardioFitness:
+		s.CardioFitness = true
+	case ScopeElectrocardiogram:
+		s.Electrocardiogram = true
+	default:
+		return false
+	}
+	return true
+}
@@ -0,0 +1,64 @@
+package fitbit
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestScopeHas(t *testing.T) {
+	s := &Scope{Activity: true, OxygenSaturation: true}
+	tests := []struct {
+		scope string
+		want  bool
+	}{
+		{"activity", true},
+		{"Activity", true},
+		{"OXYGEN_SATURATION", true},
+		{"heartrate", false},
+		{"unknown", false},
+		{"", false},
+	}
+	for _, tt := range tests {
+		if got := s.Has(tt.scope); got != tt.want {
+			t.Errorf("Has(%q) = %v, want %v", tt.scope, got, tt.want)
+		}
+	}
+	if (*Scope)(nil).Has("activity") {
+		t.Error("Has() of a nil scope = true, want false")
+	}
+}
+
+func TestScopeList(t *testing.T) {
+	if got, want := (&Scope{Activity: true, Sleep: true}).List(), []string{"activity", "sleep"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("List() = %q, want %q", got, want)
+	}
+	if got := (*Scope)(nil).List(); got == nil || len(got) != 0 {
+		t.Errorf("List() of a nil scope = %#v, want an empty slice", got)
+	}
+}
+
+func TestScopeContains(t *testing.T) {
+	s := &Scope{Activity: true, Heartrate: true}
+	tests := []struct {
+		name  string
+		scope *Scope
+		other *Scope
+		want  bool
+	}{
+		{"subset", s, &Scope{Activity: true}, true},
+		{"same", s, &Scope{Activity: true, Heartrate: true}, true},
+		{"missing", s, &Scope{Activity: true, Sleep: true}, false},
+		{"empty other", s, &Scope{}, true},
+		{"nil other", s, nil, true},
+		{"nil scope", nil, &Scope{Activity: true}, false},
+		{"nil scope with empty other", nil, &Scope{}, false},
+		{"nil scope with nil other", nil, nil, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.scope.Contains(tt.other); got != tt.want {
+				t.Errorf("Contains() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,411 @@
+package fitbit
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+const (
+	// MaxSleepLogDateRange is the maximum number of days that can be requested at once by GetSleepLogByDateRange.
+	MaxSleepLogDateRange = 100
+
+	// MaxSleepLogListLimit is the maximum number of sleep logs which can be retrieved at once.
+	MaxSleepLogListLimit = 100
+)
+
+type (
+	// SleepLevelSummary represents a summary of a sleep level.
+	SleepLevelSummary struct {
+		Count               int64 `json:"count"`
+		Minutes             int64 `json:"minutes"`
+		ThirtyDayAvgMinutes int64 `json:"thirtyDayAvgMinutes"`
+	}
+
+	// SleepLevelsSummary represents a summary of each sleep level.
+	//
+	// Deep, Light, REM and Wake are available for `stages` type sleep logs,
+	// and Asleep, Restless and Awake are available for `classic` type sleep logs.
+	SleepLevelsSummary struct {
+		Deep     *SleepLevelSummary `json:"deep"`
+		Light    *SleepLevelSummary `json:"light"`
+		REM      *SleepLevelSummary `json:"rem"`
+		Wake     *SleepLevelSummary `json:"wake"`
+		Asleep   *SleepLevelSummary `json:"asleep"`
+		Restless *SleepLevelSummary `json:"restless"`
+		Awake    *SleepLevelSummary `json:"awake"`
+	}
+
+	rawSleepLevelData struct {
+		DateTime string `json:"dateTime"`
+		Level    string `json:"level"`
+		Seconds  int64  `json:"seconds"`
+	}
+
+	// SleepLevelData represents a period of a sleep level.
+	SleepLevelData struct {
+		DateTime *time.Time // in user's local time, but the location is set to UTC
+		Level    string
+		Duration time.Duration
+	}
+
+	// SleepLevels represents sleep levels of a sleep log.
+	SleepLevels struct {
+		Data      []SleepLevelData    `json:"data"`
+		ShortData []SleepLevelData    `json:"shortData"`
+		Summary   *SleepLevelsSummary `json:"summary"`
+	}
+
+	rawSleepRecord struct {
+		DateOfSleep         string       `json:"dateOfSleep"`
+		Duration            int64        `json:"duration"` // in milliseconds
+		Efficiency          int64        `json:"efficiency"`
+		StartTime           string       `json:"startTime"`
+		EndTime             string       `json:"endTime"`
+		InfoCode            int64        `json:"infoCode"`
+		IsMainSleep         bool         `json:"isMainSleep"`
+		Levels              *SleepLevels `json:"levels"`
+		LogID               int64        `json:"logId"`
+		LogType             string       `json:"logType"`
+		MinutesAfterWakeup  int64        `json:"minutesAfterWakeup"`
+		MinutesAsleep       int64        `json:"minutesAsleep"`
+		MinutesAwake        int64        `json:"minutesAwake"`
+		MinutesToFallAsleep int64        `json:"minutesToFallAsleep"`
+		TimeInBed           int64        `json:"timeInBed"`
+		Type                string       `json:"type"`
+	}
+
+	// SleepRecord represents a user's sleep log entry.
+	//
+	// StartTime and EndTime are in user's local time, but the location is set to UTC
+	// since Fitbit APIs do not provide the timezone.
+	SleepRecord struct {
+		DateOfSleep         *time.Time
+		Duration            time.Duration
+		Efficiency          int64
+		StartTime           *time.Time
+		EndTime             *time.Time
+		InfoCode            int64
+		IsMainSleep         bool
+		Levels              *SleepLevels
+		LogID               int64
+		LogType             string
+		MinutesAfterWakeup  int64
+		MinutesAsleep       int64
+		MinutesAwake        int64
+		MinutesToFallAsleep int64
+		TimeInBed           int64
+		Type                string
+	}
+
+	// SleepStagesSummary represents total minutes of each sleep stage.
+	SleepStagesSummary struct {
+		Deep  int64 `json:"deep"`
+		Light int64 `json:"light"`
+		REM   int64 `json:"rem"`
+		Wake  int64 `json:"wake"`
+	}
+
+	// SleepSummary represents a summary of user's sleep logs.
+	SleepSummary struct {
+		Stages             *SleepStagesSummary `json:"stages"`
+		TotalMinutesAsleep int64               `json:"totalMinutesAsleep"`
+		TotalSleepRecords  int64               `json:"totalSleepRecords"`
+		TotalTimeInBed     int64               `json:"totalTimeInBed"`
+	}
+
+	// SleepLog represents a summary and list of a user's sleep log entries.
+	SleepLog struct {
+		Sleep   []SleepRecord `json:"sleep"`
+		Summary *SleepSummary `json:"summary"`
+	}
+
+	// SleepListParams represents parameters to retrieve a list of sleep logs.
+	//
+	// Either of BeforeDate or AfterDate is required.
+	// Sort must be SortDescending with BeforeDate, and SortAscending with AfterDate.
+	// It is set accordingly when it is empty.
+	// Limit must be between 1 and MaxSleepLogListLimit.
+	SleepListParams struct {
+		BeforeDate *time.Time
+		AfterDate  *time.Time
+		Sort       SortOrder
+		Limit      int
+		Offset     int // Fitbit only supports 0
+	}
+
+	rawLogSleepResponse struct {
+		Sleep *SleepRecord `json:"sleep"`
+	}
+
+	// SleepLogList represents a page of a user's sleep log entries.
+	SleepLogList struct {
+		Sleep      []SleepRecord `json:"sleep"`
+		Pagination *Pagination   `json:"pagination"`
+	}
+
+	// SleepConsistency represents a user's sleep consistency.
+	SleepConsistency struct {
+		AwakeRestlessPercentage float64 `json:"awakeRestlessPercentage"`
+		FlowID                  int64   `json:"flowId"`
+		RecommendedSleepGoal    int64   `json:"recommendedSleepGoal"`
+		TypicalDuration         int64   `json:"typicalDuration"`
+		TypicalWakeupTime       string  `json:"typicalWakeupTime"`
+	}
+
+	rawSleepGoal struct {
+		Consistency *SleepConsistency `json:"consistency"`
+		Goal        struct {
+			Bedtime     string `json:"bedtime"`
+			MinDuration int64  `json:"minDuration"`
+			UpdatedOn   string `json:"updatedOn"`
+			WakeupTime  string `json:"wakeupTime"`
+		} `json:"goal"`
+	}
+
+	// SleepGoal represents a user's sleep goal.
+	SleepGoal struct {
+		MinDuration int64 // in minutes
+		Bedtime     string
+		WakeupTime  string
+		UpdatedOn   *time.Time
+		Consistency *SleepConsistency // only available on GetSleepGoal
+	}
+)
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (d *SleepLevelData) UnmarshalJSON(b []byte) error {
+	var raw rawSleepLevelData
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return err
+	}
+
+	dateTime, err := parseTime(localDateTimeFormat, raw.DateTime)
+	if err != nil {
+		return err
+	}
+
+	d.DateTime = dateTime
+	d.Level = raw.Level
+	d.Duration = time.Duration(raw.Seconds) * time.Second
+	return nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (r *SleepRecord) UnmarshalJSON(b []byte) error {
+	var raw rawSleepRecord
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return err
+	}
+
+	dateOfSleep, err := parseTime(dateFormat, raw.DateOfSleep)
+	if err != nil {
+		return err
+	}
+	startTime, err := parseTime(localDateTimeFormat, raw.StartTime)
+	if err != nil {
+		return err
+	}
+	endTime, err := parseTime(localDateTimeFormat, raw.EndTime)
+	if err != nil {
+		return err
+	}
+
+	r.DateOfSleep = dateOfSleep
+	r.Duration = time.Duration(raw.Duration) * time.Millisecond
+	r.Efficiency = raw.Efficiency
+	r.StartTime = startTime
+	r.EndTime = endTime
+	r.InfoCode = raw.InfoCode
+	r.IsMainSleep = raw.IsMainSleep
+	r.Levels = raw.Levels
+	r.LogID = raw.LogID
+	r.LogType = raw.LogType
+	r.MinutesAfterWakeup = raw.MinutesAfterWakeup
+	r.MinutesAsleep = raw.MinutesAsleep
+	r.MinutesAwake = raw.MinutesAwake
+	r.MinutesToFallAsleep = raw.MinutesToFallAsleep
+	r.TimeInBed = raw.TimeInBed
+	r.Type = raw.Type
+	return nil
+}
+
+// GetSleepLogByDate retrieves a list of a user's sleep log entries for a given date.
+//
+// Scope.Sleep is required.
+//
+// Web API Reference: https://dev.fitbit.com/build/reference/web-api/sleep/get-sleep-log-by-date/
+func (c *Client) GetSleepLogByDate(ctx context.Context, userID string, date time.Time, token *Token) (*SleepLog, *RateLimit, []byte, error) {
+	endpoint := c.getEndpoint("GetSleepLogByDate", resolveUserID(userID), date.Format(dateFormat))
+	return c.getSleepLog(ctx, token, endpoint)
+}
+
+// GetSleepLogByDateRange retrieves a list of a user's sleep log entries for a date range.
+//
+// The date range must not exceed MaxSleepLogDateRange days.
+//
+// Scope.Sleep is required.
+//
+// Web API Reference: https://dev.fitbit.com/build/reference/web-api/sleep/get-sleep-log-by-date-range/
+func (c *Client) GetSleepLogByDateRange(ctx context.Context, userID string, start, end time.Time, token *Token) (*SleepLog, *RateLimit, []byte, error) {
+	if err := validateDateRange(start, end, MaxSleepLogDateRange); err != nil {
+		return nil, nil, nil, err
+	}
+	endpoint := c.getEndpoint("GetSleepLogByDateRange", resolveUserID(userID), start.Format(dateFormat), end.Format(dateFormat))
+	return c.getSleepLog(ctx, token, endpoint)
+}
+
+// GetSleepLogList retrieves a page of the user's sleep log list.
+//
+// Pagination of the result holds the URLs of the next and previous pages,
+// which can be followed by FollowPagination.
+//
+// Scope.Sleep is required.
+//
+// Web API Reference: https://dev.fitbit.com/build/reference/web-api/sleep/get-sleep-log-list/
+func (c *Client) GetSleepLogList(ctx context.Context, userID string, params SleepListParams, token *Token) (*SleepLogList, *RateLimit, []byte, error) {
+	query, err := listQuery(params.BeforeDate, params.AfterDate, params.Sort, params.Limit, params.Offset, MaxSleepLogListLimit)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	endpoint := c.getEndpoint("GetSleepLogList", resolveUserID(userID), query.Encode())
+	b, rateLimit, err := c.getRequest(ctx, token, endpoint)
+	if err != nil {
+		return nil, nil, b, err
+	}
+	var list SleepLogList
+	if err := json.Unmarshal(b, &list); err != nil {
+		return nil, rateLimit, b, err
+	}
+	if list.Sleep == nil {
+		list.Sleep = []SleepRecord{}
+	}
+	return &list, rateLimit, b, nil
+}
+
+// LogSleep creates a sleep log entry which starts at `start` in user's local time and lasts for `duration`.
+//
+// Only the date, hour and minute of `start` are used.
+// The date must not be in the future, allowing one day ahead since the user's timezone may be ahead of `start`.
+// The created entry is returned with LogID, and the sleep levels inferred by Fitbit if any.
+//
+// Scope.Sleep is required.
+//
+// Web API Reference: https://dev.fitbit.com/build/reference/web-api/sleep/create-sleep-log/
+func (c *Client) LogSleep(ctx context.Context, userID string, start time.Time, duration time.Duration, token *Token) (*SleepRecord, *RateLimit, []byte, error) {
+	if duration <= 0 {
+		return nil, nil, nil, errors.New("fitbit: duration must be positive")
+	}
+	if isFutureDate(start) {
+		return nil, nil, nil, errors.New("fitbit: start date must not be in the future")
+	}
+	endpoint := c.getEndpoint("LogSleep", resolveUserID(userID))
+	values := url.Values{}
+	values.Set("startTime", start.Format("15:04"))
+	values.Set("duration", strconv.FormatInt(duration.Milliseconds(), 10))
+	values.Set("date", start.Format(dateFormat))
+	b, rateLimit, err := c.postRequest(ctx, token, endpoint, values)
+	if err != nil {
+		return nil, nil, b, err
+	}
+	var resp rawLogSleepResponse
+	if err := json.Unmarshal(b, &resp); err != nil {
+		return nil, rateLimit, b, err
+	}
+	return resp.Sleep, rateLimit, b, nil
+}
+
+// DeleteSleepLog deletes a sleep log entry.
+//
+// Scope.Sleep is required.
+//
+// Web API Reference: https://dev.fitbit.com/build/reference/web-api/sleep/delete-sleep-log/
+func (c *Client) DeleteSleepLog(ctx context.Context, userID string, logID int64, token *Token) (*RateLimit, error) {
+	if err := validateLogID(logID); err != nil {
+		return nil, err
+	}
+	endpoint := c.getEndpoint("DeleteSleepLog", resolveUserID(userID), logID)
+	_, rateLimit, err := c.deleteRequest(ctx, token, endpoint)
+	if err != nil {
+		return nil, err
+	}
+	return rateLimit, nil
+}
+
+func (c *Client) getSleepLog(ctx context.Context, token *Token, endpoint endpointURL) (*SleepLog, *RateLimit, []byte, error) {
+	b, rateLimit, err := c.getRequest(ctx, token, endpoint)
+	if err != nil {
+		return nil, nil, b, err
+	}
+	var sleepLog SleepLog
+	if err := json.Unmarshal(b, &sleepLog); err != nil {
+		return nil, rateLimit, b, err
+	}
+	return &sleepLog, rateLimit, b, nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (g *SleepGoal) UnmarshalJSON(b []byte) error {
+	var raw rawSleepGoal
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return err
+	}
+
+	updatedOn, err := parseTime(time.RFC3339, raw.Goal.UpdatedOn)
+	if err != nil {
+		return err
+	}
+
+	g.MinDuration = raw.Goal.MinDuration
+	g.Bedtime = raw.Goal.Bedtime
+	g.WakeupTime = raw.Goal.WakeupTime
+	g.UpdatedOn = updatedOn
+	g.Consistency = raw.Consistency
+	return nil
+}
+
+// GetSleepGoal retrieves a user's current sleep goal.
+//
+// Scope.Sleep is required.
+//
+// Web API Reference: https://dev.fitbit.com/build/reference/web-api/sleep/get-sleep-goals/
+func (c *Client) GetSleepGoal(ctx context.Context, userID string, token *Token) (*SleepGoal, *RateLimit, []byte, error) {
+	endpoint := c.getEndpoint("GetSleepGoal", resolveUserID(userID))
+	b, rateLimit, err := c.getRequest(ctx, token, endpoint)
+	if err != nil {
+		return nil, nil, b, err
+	}
+	var sleepGoal SleepGoal
+	if err := json.Unmarshal(b, &sleepGoal); err != nil {
+		return nil, rateLimit, b, err
+	}
+	return &sleepGoal, rateLimit, b, nil
+}
+
+// UpdateSleepGoal updates a user's sleep goal, and returns the updated goal.
+//
+// `minDuration` is the target sleep duration in minutes, and must be positive.
+//
+// Scope.Sleep is required.
+//
+// Web API Reference: https://dev.fitbit.com/build/reference/web-api/sleep/create-sleep-goals/
+func (c *Client) UpdateSleepGoal(ctx context.Context, userID string, minDuration int64, token *Token) (*SleepGoal, *RateLimit, []byte, error) {
+	if minDuration <= 0 {
+		return nil, nil, nil, errors.New("fitbit: minDuration must be positive")
+	}
+	endpoint := c.getEndpoint("UpdateSleepGoal", resolveUserID(userID))
+	values := url.Values{}
+	values.Set("minDuration", strconv.FormatInt(minDuration, 10))
+	b, rateLimit, err := c.postRequest(ctx, token, endpoint, values)
+	if err != nil {
+		return nil, nil, b, err
+	}
+	var sleepGoal SleepGoal
+	if err := json.Unmarshal(b, &sleepGoal); err != nil {
+		return nil, rateLimit, b, err
+	}
+	return &sleepGoal, rateLimit, b, nil
+}
@@ -0,0 +1,151 @@
+package fitbit
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+)
+
+type (
+	rawPersonAttributes struct {
+		Avatar string `json:"avatar"`
+		Child  bool   `json:"child"`
+		Friend bool   `json:"friend"`
+		Name   string `json:"name"`
+	}
+
+	rawPerson struct {
+		Type       string              `json:"type"`
+		ID         string              `json:"id"`
+		Attributes rawPersonAttributes `json:"attributes"`
+	}
+
+	rawFriendsResponse struct {
+		Data []rawPerson `json:"data"`
+	}
+
+	rawLeaderboardEntry struct {
+		Type       string `json:"type"`
+		ID         string `json:"id"`
+		Attributes struct {
+			StepRank    int64 `json:"step-rank"`
+			StepSummary int64 `json:"step-summary"`
+		} `json:"attributes"`
+		Relationships struct {
+			User struct {
+				Data struct {
+					Type string `json:"type"`
+					ID   string `json:"id"`
+				} `json:"data"`
+			} `json:"user"`
+		} `json:"relationships"`
+	}
+
+	rawLeaderboardResponse struct {
+		Data     []rawLeaderboardEntry `json:"data"`
+		Included []rawPerson           `json:"included"`
+	}
+
+	// Friend represents a user's friend.
+	Friend struct {
+		ID     string
+		Type   string
+		Name   string // display name
+		Avatar *url.URL
+		Child  bool
+		Friend bool
+	}
+
+	// LeaderboardEntry represents an entry of the friends leaderboard.
+	//
+	// StepRank and StepSummary are 0 for inactive users.
+	LeaderboardEntry struct {
+		ID          string
+		Type        string // ranked-user or inactive-user
+		StepRank    int64
+		StepSummary int64
+		Friend      *Friend // the user related to the entry, nil if it is not included in the response
+	}
+)
+
+func (p *rawPerson) friend() (*Friend, error) {
+	avatar, err := url.Parse(p.Attributes.Avatar)
+	if err != nil {
+		return nil, err
+	}
+	return &Friend{
+		ID:     p.ID,
+		Type:   p.Type,
+		Name:   p.Attributes.Name,
+		Avatar: avatar,
+		Child:  p.Attributes.Child,
+		Friend: p.Attributes.Friend,
+	}, nil
+}
+
+// GetFriends retrieves a list of the user's friends.
+//
+// An empty slice is returned when the user has no friends.
+//
+// Scope.Social is required.
+//
+// Web API Reference: https://dev.fitbit.com/build/reference/web-api/friends/get-friends/
+func (c *Client) GetFriends(ctx context.Context, userID string, token *Token) ([]Friend, *RateLimit, []byte, error) {
+	endpoint := c.getEndpoint("GetFriends", resolveUserID(userID))
+	b, rateLimit, err := c.getRequest(ctx, token, endpoint)
+	if err != nil {
+		return nil, nil, b, err
+	}
+	var raw rawFriendsResponse
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return nil, rateLimit, b, err
+	}
+	friends := make([]Friend, 0, len(raw.Data))
+	for i := range raw.Data {
+		friend, err := raw.Data[i].friend()
+		if err != nil {
+			return nil, rateLimit, b, err
+		}
+		friends = append(friends, *friend)
+	}
+	return friends, rateLimit, b, nil
+}
+
+// GetFriendsLeaderboard retrieves the leaderboard of the user and the user's friends.
+//
+// Each entry is resolved with the related user from the `included` part of the response.
+//
+// Scope.Social is required.
+//
+// Web API Reference: https://dev.fitbit.com/build/reference/web-api/friends/get-friends-leaderboard/
+func (c *Client) GetFriendsLeaderboard(ctx context.Context, userID string, token *Token) ([]LeaderboardEntry, *RateLimit, []byte, error) {
+	endpoint := c.getEndpoint("GetFriendsLeaderboard", resolveUserID(userID))
+	b, rateLimit, err := c.getRequest(ctx, token, endpoint)
+	if err != nil {
+		return nil, nil, b, err
+	}
+	var raw rawLeaderboardResponse
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return nil, rateLimit, b, err
+	}
+	included := make(map[string]*Friend, len(raw.Included))
+	for i := range raw.Included {
+		friend, err := raw.Included[i].friend()
+		if err != nil {
+			return nil, rateLimit, b, err
+		}
+		included[raw.Included[i].Type+"/"+raw.Included[i].ID] = friend
+	}
+	entries := make([]LeaderboardEntry, 0, len(raw.Data))
+	for _, d := range raw.Data {
+		related := d.Relationships.User.Data
+		entries = append(entries, LeaderboardEntry{
+			ID:          d.ID,
+			Type:        d.Type,
+			StepRank:    d.Attributes.StepRank,
+			StepSummary: d.Attributes.StepSummary,
+			Friend:      included[related.Type+"/"+related.ID],
+		})
+	}
+	return entries, rateLimit, b, nil
+}
@@ -0,0 +1,101 @@
+package fitbit
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+)
+
+// MaxSpO2DateRange is the maximum number of days which can be retrieved by GetSpO2SummaryByInterval.
+const MaxSpO2DateRange = 30
+
+type (
+	rawSpO2Summary struct {
+		DateTime string `json:"dateTime"`
+		Value    struct {
+			Avg float64 `json:"avg"`
+			Min float64 `json:"min"`
+			Max float64 `json:"max"`
+		} `json:"value"`
+	}
+
+	// SpO2Summary represents a daily summary of oxygen saturation (SpO2) in percentage.
+	SpO2Summary struct {
+		Date *time.Time
+		Avg  float64
+		Min  float64
+		Max  float64
+	}
+)
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (s *SpO2Summary) UnmarshalJSON(b []byte) error {
+	var raw rawSpO2Summary
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return err
+	}
+
+	date, err := parseTime(dateFormat, raw.DateTime)
+	if err != nil {
+		return err
+	}
+
+	s.Date = date
+	s.Avg = raw.Value.Avg
+	s.Min = raw.Value.Min
+	s.Max = raw.Value.Max
+	return nil
+}
+
+// GetSpO2Summary retrieves the SpO2 summary of the specified date.
+//
+// nil is returned when there is no data for the date.
+//
+// Scope.OxygenSaturation is required.
+//
+// Web API Reference: https://dev.fitbit.com/build/reference/web-api/spo2/get-spo2-summary-by-date/
+func (c *Client) GetSpO2Summary(ctx context.Context, userID string, date time.Time, token *Token) (*SpO2Summary, *RateLimit, []byte, error) {
+	endpoint := c.getEndpoint("GetSpO2Summary", resolveUserID(userID), date.Format(dateFormat))
+	b, rateLimit, err := c.getRequest(ctx, token, endpoint)
+	if err != nil {
+		return nil, nil, b, err
+	}
+	var summary SpO2Summary
+	if err := json.Unmarshal(b, &summary); err != nil {
+		return nil, rateLimit, b, err
+	}
+	if summary.Date == nil {
+		return nil, rateLimit, b, nil
+	}
+	return &summary, rateLimit, b, nil
+}
+
+// GetSpO2SummaryByInterval retrieves the SpO2 summaries between `start` and `end`.
+//
+// Fitbit omits days without data, so only the days having data are returned.
+// The date range must not exceed MaxSpO2DateRange days.
+//
+// Scope.OxygenSaturation is required.
+//
+// Web API Reference: https://dev.fitbit.com/build/reference/web-api/spo2/get-spo2-summary-by-interval/
+func (c *Client) GetSpO2SummaryByInterval(ctx context.Context, userID string, start, end time.Time, token *Token) ([]SpO2Summary, *RateLimit, []byte, error) {
+	if err := validateDateRange(start, end, MaxSpO2DateRange); err != nil {
+		return nil, nil, nil, err
+	}
+	endpoint := c.getEndpoint("GetSpO2SummaryByInterval", resolveUserID(userID), start.Format(dateFormat), end.Format(dateFormat))
+	b, rateLimit, err := c.getRequest(ctx, token, endpoint)
+	if err != nil {
+		return nil, nil, b, err
+	}
+	var raw []SpO2Summary
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return nil, rateLimit, b, err
+	}
+	summaries := make([]SpO2Summary, 0, len(raw))
+	for _, s := range raw {
+		if s.Date != nil {
+			summaries = append(summaries, s)
+		}
+	}
+	return summaries, rateLimit, b, nil
+}
@@ -0,0 +1,264 @@
+package fitbit
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha1"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"time"
+)
+
+// Collection represents the collection of data to subscribe.
+type Collection string
+
+const (
+	CollectionActivities        Collection = "activities"
+	CollectionBody              Collection = "body"
+	CollectionFoods             Collection = "foods"
+	CollectionSleep             Collection = "sleep"
+	CollectionUserRevokedAccess Collection = "userRevokedAccess"
+	CollectionAll               Collection = "" // CollectionAll represents all collections
+)
+
+// subscribableCollections are the collections of data which CreateSubscriptionsForAll subscribes to.
+var subscribableCollections = []Collection{CollectionActivities, CollectionBody, CollectionFoods, CollectionSleep}
+
+// pathPrefix returns the part of the endpoint path which specifies the collection.
+func (col Collection) pathPrefix() string {
+	if col == CollectionAll {
+		return ""
+	}
+	return string(col) + "/"
+}
+
+type (
+	// Subscription represents a subscription to a user's data.
+	Subscription struct {
+		CollectionType Collection `json:"collectionType"`
+		OwnerID        string     `json:"ownerId"`
+		OwnerType      string     `json:"ownerType"`
+		SubscriberID   string     `json:"subscriberId"`
+		SubscriptionID string     `json:"subscriptionId"`
+	}
+
+	rawListSubscriptionsResponse struct {
+		APISubscriptions []Subscription `json:"apiSubscriptions"`
+	}
+
+	rawSubscriptionNotification struct {
+		CollectionType Collection `json:"collectionType"`
+		Date           string     `json:"date"`
+		OwnerID        string     `json:"ownerId"`
+		OwnerType      string     `json:"ownerType"`
+		SubscriptionID string     `json:"subscriptionId"`
+	}
+
+	// SubscriptionNotification represents a notification sent to the subscriber endpoint.
+	SubscriptionNotification struct {
+		CollectionType Collection
+		Date           *time.Time
+		OwnerID        string
+		OwnerType      string
+		SubscriptionID string
+	}
+)
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (n *SubscriptionNotification) UnmarshalJSON(b []byte) error {
+	var raw rawSubscriptionNotification
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return err
+	}
+
+	date, err := parseTime(dateFormat, raw.Date)
+	if err != nil {
+		return err
+	}
+
+	n.CollectionType = raw.CollectionType
+	n.Date = date
+	n.OwnerID = raw.OwnerID
+	n.OwnerType = raw.OwnerType
+	n.SubscriptionID = raw.SubscriptionID
+	return nil
+}
+
+// ParseSubscriptionNotifications parses notifications which Fitbit sends to the subscriber endpoint.
+//
+// Web API Reference: https://dev.fitbit.com/build/reference/web-api/developer-guide/using-subscriptions/#Receiving-Notifications
+func ParseSubscriptionNotifications(r io.Reader) ([]SubscriptionNotification, error) {
+	var notifications []SubscriptionNotification
+	if err := json.NewDecoder(r).Decode(&notifications); err != nil {
+		return nil, err
+	}
+	if notifications == nil {
+		notifications = []SubscriptionNotification{}
+	}
+	return notifications, nil
+}
+
+// VerifySubscriptionRequest reports whether the request is a verification request
+// from Fitbit with the correct verification code.
+//
+// The subscriber endpoint should respond with 204 No Content when it returns true,
+// and 404 Not Found otherwise.
+//
+// Web API Reference: https://dev.fitbit.com/build/reference/web-api/developer-guide/using-subscriptions/#Verifying-a-Subscriber
+func VerifySubscriptionRequest(r *http.Request, verificationCode string) bool {
+	if r.Method != http.MethodGet || verificationCode == "" {
+		return false
+	}
+	verify := r.URL.Query().Get("verify")
+	return subtle.ConstantTimeCompare([]byte(verify), []byte(verificationCode)) == 1
+}
+
+func (c *Client) subscriptionRequest(ctx context.Context, token *Token, op, method string, endpoint endpointURL, subscriberID string) ([]byte, *RateLimit, error) {
+	req, err := http.NewRequest(method, endpoint.url, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	if subscriberID != "" {
+		req.Header.Set("X-Fitbit-Subscriber-Id", subscriberID)
+	}
+	b, rateLimit, err := c.request(ctx, token, endpoint.label, req)
+	if apiErr := (*APIError)(nil); method == http.MethodDelete && c.idempotentDel && errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound {
+		return nil, rateLimit, nil
+	}
+	return b, rateLimit, wrapAsRequestError(op, endpoint.url, err)
+}
+
+// CreateSubscription creates a subscription to notify changes of a user's data in the collection.
+//
+// When `subscriberID` is empty, the default subscriber configured for the application is used.
+//
+// The scope corresponding to the collection is required.
+//
+// Web API Reference: https://dev.fitbit.com/build/reference/web-api/subscription/create-subscription/
+func (c *Client) CreateSubscription(ctx context.Context, userID string, collection Collection, subscriptionID, subscriberID string, token *Token) (*Subscription, *RateLimit, []byte, error) {
+	endpoint := c.getEndpoint("CreateSubscription", resolveUserID(userID), collection.pathPrefix(), subscriptionID)
+	b, rateLimit, err := c.subscriptionRequest(ctx, token, "Post", http.MethodPost, endpoint, subscriberID)
+	if err != nil {
+		return nil, nil, b, err
+	}
+	var subscription Subscription
+	if err := json.Unmarshal(b, &subscription); err != nil {
+		return nil, rateLimit, b, err
+	}
+	return &subscription, rateLimit, b, nil
+}
+
+// DeleteSubscription deletes a subscription.
+//
+// Web API Reference: https://dev.fitbit.com/build/reference/web-api/subscription/delete-subscription/
+func (c *Client) DeleteSubscription(ctx context.Context, userID string, collection Collection, subscriptionID, subscriberID string, token *Token) (*RateLimit, error) {
+	endpoint := c.getEndpoint("DeleteSubscription", resolveUserID(userID), collection.pathPrefix(), subscriptionID)
+	_, rateLimit, err := c.subscriptionRequest(ctx, token, "Delete", http.MethodDelete, endpoint, subscriberID)
+	if err != nil {
+		return nil, err
+	}
+	return rateLimit, nil
+}
+
+// CreateSubscriptionsForAll creates subscriptions to each of CollectionActivities, CollectionBody, CollectionFoods and CollectionSleep,
+// and returns the created subscriptions in that order.
+//
+// The subscription IDs are derived from `userID` like "<userID>-activities" to be unique,
+// so `userID` must be specified explicitly rather than CurrentUserID.
+// When `subscriberID` is empty, the default subscriber configured for the application is used.
+// When some of the requests fail, the created subscriptions are returned with *BatchError keyed by the collections.
+// Use LastRateLimit to obtain the rate limit.
+//
+// The scopes corresponding to the collections are required.
+func (c *Client) CreateSubscriptionsForAll(ctx context.Context, userID, subscriberID string, token *Token) ([]Subscription, error) {
+	if userID == "" || userID == CurrentUserID {
+		return nil, errors.New("fitbit: user ID must be specified to derive subscription IDs")
+	}
+	subscriptions := []Subscription{}
+	errs := make(map[string]error)
+	for _, collection := range subscribableCollections {
+		subscription, _, _, err := c.CreateSubscription(ctx, userID, collection, userID+"-"+string(collection), subscriberID, token)
+		if err != nil {
+			errs[string(collection)] = err
+			continue
+		}
+		subscriptions = append(subscriptions, *subscription)
+	}
+	if len(errs) > 0 {
+		return subscriptions, &BatchError{Errors: errs}
+	}
+	return subscriptions, nil
+}
+
+// DeleteAllSubscriptions deletes all the subscriptions created by the application for the user,
+// and returns the deleted subscriptions.
+//
+// When `subscriberID` is not empty, only the subscriptions of the subscriber are deleted.
+// When some of the requests fail, the deleted subscriptions are returned with *BatchError keyed by the subscription IDs.
+// Use LastRateLimit to obtain the rate limit.
+func (c *Client) DeleteAllSubscriptions(ctx context.Context, userID, subscriberID string, token *Token) ([]Subscription, error) {
+	subscriptions, _, _, err := c.ListSubscriptions(ctx, userID, CollectionAll, token)
+	if err != nil {
+		return nil, err
+	}
+	deleted := []Subscription{}
+	errs := make(map[string]error)
+	for _, subscription := range subscriptions {
+		if subscriberID != "" && subscription.SubscriberID != subscriberID {
+			continue
+		}
+		if _, err := c.DeleteSubscription(ctx, userID, subscription.CollectionType, subscription.SubscriptionID, subscription.SubscriberID, token); err != nil {
+			errs[subscription.SubscriptionID] = err
+			continue
+		}
+		deleted = append(deleted, subscription)
+	}
+	if len(errs) > 0 {
+		return deleted, &BatchError{Errors: errs}
+	}
+	return deleted, nil
+}
+
+// ListSubscriptions retrieves a list of subscriptions created by the application for the user.
+//
+// Web API Reference: https://dev.fitbit.com/build/reference/web-api/subscription/get-subscription-list/
+func (c *Client) ListSubscriptions(ctx context.Context, userID string, collection Collection, token *Token) ([]Subscription, *RateLimit, []byte, error) {
+	endpoint := c.getEndpoint("ListSubscriptions", resolveUserID(userID), collection.pathPrefix())
+	b, rateLimit, err := c.getRequest(ctx, token, endpoint)
+	if err != nil {
+		return nil, nil, b, err
+	}
+	var resp rawListSubscriptionsResponse
+	if err := json.Unmarshal(b, &resp); err != nil {
+		return nil, rateLimit, b, err
+	}
+	if resp.APISubscriptions == nil {
+		resp.APISubscriptions = []Subscription{}
+	}
+	return resp.APISubscriptions, rateLimit, b, nil
+}
+
+// VerifyNotificationSignature reports whether `signature`, the value of X-Fitbit-Signature header,
+// is valid for the notification `body`.
+//
+// The signature is the base64 encoded HMAC-SHA1 of the body,
+// using the client secret followed by '&' as the key.
+// It returns false when `clientSecret` is empty.
+//
+// Web API Reference: https://dev.fitbit.com/build/reference/web-api/developer-guide/best-practices/#Subscriber-Security
+func VerifyNotificationSignature(body []byte, signature, clientSecret string) bool {
+	if clientSecret == "" {
+		return false
+	}
+	expected, err := base64.StdEncoding.DecodeString(signature)
+	if err != nil {
+		return false
+	}
+	mac := hmac.New(sha1.New, []byte(clientSecret+"&"))
+	mac.Write(body)
+	return hmac.Equal(mac.Sum(nil), expected)
+}
@@ -0,0 +1,60 @@
+package fitbit
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestVerifyNotificationSignature(t *testing.T) {
+	// The notification body follows the example in Fitbit's documentation of subscriptions,
+	// and the signature is computed independently as documented: base64(HMAC-SHA1(body, clientSecret + "&")).
+	const (
+		body         = `[{"collectionType":"foods","date":"2020-06-01","ownerId":"228TQ4","ownerType":"user","subscriptionId":"1234"}]`
+		clientSecret = "8a4e3b6e5ee0b1c0b4d3f2a1c9e8d7f6"
+		signature    = "bLn/urpuZGU9z7fNpBt+I9DPpDQ="
+	)
+	tests := []struct {
+		name         string
+		body         string
+		signature    string
+		clientSecret string
+		want         bool
+	}{
+		{"valid", body, signature, clientSecret, true},
+		{"tampered body", body + " ", signature, clientSecret, false},
+		{"wrong secret", body, signature, "wrong-secret", false},
+		{"empty secret", body, signature, "", false},
+		{"not base64", body, "not base64", clientSecret, false},
+		{"empty signature", body, "", clientSecret, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := VerifyNotificationSignature([]byte(tt.body), tt.signature, tt.clientSecret); got != tt.want {
+				t.Errorf("VerifyNotificationSignature() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDeleteSubscriptionIdempotent(t *testing.T) {
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"errors":[{"errorType":"not_found","message":"The resource with given id doesn't exist"}],"success":false}`))
+	}))
+	token := &Token{AccessToken: "access-token", TokenType: "Bearer", Expiry: time.Now().Add(time.Hour)}
+
+	_, err := c.DeleteSubscription(context.Background(), "ABC123", CollectionActivities, "ABC123-activities", "", token)
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusNotFound {
+		t.Fatalf("DeleteSubscription() error = %v, want *APIError of 404", err)
+	}
+
+	c.SetIdempotentDeletes(true)
+	if _, err := c.DeleteSubscription(context.Background(), "ABC123", CollectionActivities, "ABC123-activities", "", token); err != nil {
+		t.Errorf("DeleteSubscription() error = %v, want nil", err)
+	}
+}
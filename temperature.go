@@ -0,0 +1,189 @@
+package fitbit
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// MaxTemperatureDateRange is the maximum number of days which can be retrieved by
+// GetSkinTemperature and GetCoreTemperature.
+const MaxTemperatureDateRange = 30
+
+// Temperature units, which are the values of Unit.Temperature.
+const (
+	TemperatureCelsius    = "°C" // TemperatureCelsius represents degrees Celsius
+	TemperatureFahrenheit = "°F" // TemperatureFahrenheit represents degrees Fahrenheit
+)
+
+type (
+	rawSkinTemperature struct {
+		DateTime string `json:"dateTime"`
+		Value    struct {
+			NightlyRelative float64 `json:"nightlyRelative"`
+		} `json:"value"`
+		LogType string `json:"logType"`
+	}
+
+	rawSkinTemperatureResponse struct {
+		TempSkin []SkinTemperature `json:"tempSkin"`
+	}
+
+	// SkinTemperature represents the skin temperature measured during the main sleep of a day.
+	//
+	// NightlyRelative is the difference from the user's baseline,
+	// in the temperature unit of the language setting.
+	SkinTemperature struct {
+		Date            *time.Time
+		NightlyRelative float64
+		LogType         string
+	}
+
+	rawCoreTemperature struct {
+		DateTime string  `json:"dateTime"`
+		Value    float64 `json:"value"`
+	}
+
+	rawCoreTemperatureResponse struct {
+		TempCore []CoreTemperature `json:"tempCore"`
+	}
+
+	// CoreTemperature represents the core temperature logged by the user.
+	//
+	// Value is in the temperature unit of the language setting.
+	CoreTemperature struct {
+		DateTime *time.Time // in user's local time, but the location is set to UTC
+		Value    float64
+	}
+)
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (st *SkinTemperature) UnmarshalJSON(b []byte) error {
+	var raw rawSkinTemperature
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return err
+	}
+
+	date, err := parseTime(dateFormat, raw.DateTime)
+	if err != nil {
+		return err
+	}
+
+	st.Date = date
+	st.NightlyRelative = raw.Value.NightlyRelative
+	st.LogType = raw.LogType
+	return nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (ct *CoreTemperature) UnmarshalJSON(b []byte) error {
+	var raw rawCoreTemperature
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return err
+	}
+
+	dateTime, err := parseTime("2006-01-02T15:04:05", raw.DateTime)
+	if err != nil {
+		return err
+	}
+
+	ct.DateTime = dateTime
+	ct.Value = raw.Value
+	return nil
+}
+
+// ConvertTemperature converts the absolute temperature `value` from the unit `from` to the unit `to`.
+//
+// Units are either TemperatureCelsius or TemperatureFahrenheit, like Unit.Temperature.
+func ConvertTemperature(value float64, from, to string) (float64, error) {
+	if err := validateTemperatureUnits(from, to); err != nil {
+		return 0, err
+	}
+	switch {
+	case from == to:
+		return value, nil
+	case to == TemperatureFahrenheit:
+		return value*9/5 + 32, nil
+	default:
+		return (value - 32) * 5 / 9, nil
+	}
+}
+
+// ConvertTemperatureDifference converts the temperature difference `value`,
+// like SkinTemperature.NightlyRelative, from the unit `from` to the unit `to`.
+func ConvertTemperatureDifference(value float64, from, to string) (float64, error) {
+	if err := validateTemperatureUnits(from, to); err != nil {
+		return 0, err
+	}
+	switch {
+	case from == to:
+		return value, nil
+	case to == TemperatureFahrenheit:
+		return value * 9 / 5, nil
+	default:
+		return value * 5 / 9, nil
+	}
+}
+
+func validateTemperatureUnits(units ...string) error {
+	for _, unit := range units {
+		if unit != TemperatureCelsius && unit != TemperatureFahrenheit {
+			return fmt.Errorf("fitbit: invalid temperature unit %q", unit)
+		}
+	}
+	return nil
+}
+
+// GetSkinTemperature retrieves the skin temperatures between `start` and `end`.
+//
+// Fitbit omits days without data, so only the days having data are returned.
+// The date range must not exceed MaxTemperatureDateRange days.
+//
+// Scope.Temperature is required.
+//
+// Web API Reference: https://dev.fitbit.com/build/reference/web-api/temperature/get-temperature-skin-summary-by-interval/
+func (c *Client) GetSkinTemperature(ctx context.Context, userID string, start, end time.Time, token *Token) ([]SkinTemperature, *RateLimit, []byte, error) {
+	if err := validateDateRange(start, end, MaxTemperatureDateRange); err != nil {
+		return nil, nil, nil, err
+	}
+	endpoint := c.getEndpoint("GetSkinTemperature", resolveUserID(userID), start.Format(dateFormat), end.Format(dateFormat))
+	b, rateLimit, err := c.getRequest(ctx, token, endpoint)
+	if err != nil {
+		return nil, nil, b, err
+	}
+	var raw rawSkinTemperatureResponse
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return nil, rateLimit, b, err
+	}
+	if raw.TempSkin == nil {
+		raw.TempSkin = []SkinTemperature{}
+	}
+	return raw.TempSkin, rateLimit, b, nil
+}
+
+// GetCoreTemperature retrieves the core temperatures logged between `start` and `end`.
+//
+// The date range must not exceed MaxTemperatureDateRange days.
+//
+// Scope.Temperature is required.
+//
+// Web API Reference: https://dev.fitbit.com/build/reference/web-api/temperature/get-temperature-core-summary-by-interval/
+func (c *Client) GetCoreTemperature(ctx context.Context, userID string, start, end time.Time, token *Token) ([]CoreTemperature, *RateLimit, []byte, error) {
+	if err := validateDateRange(start, end, MaxTemperatureDateRange); err != nil {
+		return nil, nil, nil, err
+	}
+	endpoint := c.getEndpoint("GetCoreTemperature", resolveUserID(userID), start.Format(dateFormat), end.Format(dateFormat))
+	b, rateLimit, err := c.getRequest(ctx, token, endpoint)
+	if err != nil {
+		return nil, nil, b, err
+	}
+	var raw rawCoreTemperatureResponse
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return nil, rateLimit, b, err
+	}
+	if raw.TempCore == nil {
+		raw.TempCore = []CoreTemperature{}
+	}
+	return raw.TempCore, rateLimit, b, nil
+}
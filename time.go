@@ -10,6 +10,14 @@ func parseTime(layout, value string) (*time.Time, error) {
 	return &t, err
 }
 
+func parseTimeInLocation(layout, value string, loc *time.Location) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+	t, err := time.ParseInLocation(layout, value, loc)
+	return &t, err
+}
+
 func timeValue(t *time.Time) time.Time {
 	if t == nil {
 		return time.Time{}
@@ -23,3 +31,10 @@ func timeRef(t time.Time) *time.Time {
 	}
 	return &t
 }
+
+// daysBetween returns the number of calendar days from `start` to `end`.
+func daysBetween(start, end time.Time) int {
+	startDate := time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, time.UTC)
+	endDate := time.Date(end.Year(), end.Month(), end.Day(), 0, 0, 0, 0, time.UTC)
+	return int(endDate.Sub(startDate).Hours() / 24)
+}
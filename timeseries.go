@@ -0,0 +1,88 @@
+package fitbit
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// Period represents the range of time series data ending at a date.
+type Period string
+
+const (
+	Period1d  Period = "1d"  // Period1d represents 1 day
+	Period7d  Period = "7d"  // Period7d represents 7 days
+	Period30d Period = "30d" // Period30d represents 30 days
+	Period1w  Period = "1w"  // Period1w represents 1 week
+	Period1m  Period = "1m"  // Period1m represents 1 month
+	Period3m  Period = "3m"  // Period3m represents 3 months
+	Period6m  Period = "6m"  // Period6m represents 6 months
+	Period1y  Period = "1y"  // Period1y represents 1 year
+)
+
+func (p Period) validate() error {
+	switch p {
+	case Period1d, Period7d, Period30d, Period1w, Period1m, Period3m, Period6m, Period1y:
+		return nil
+	}
+	return fmt.Errorf("fitbit: unsupported period %q", string(p))
+}
+
+type (
+	rawTimeSeriesPoint struct {
+		DateTime string          `json:"dateTime"`
+		Value    json.RawMessage `json:"value"`
+	}
+
+	// TimeSeriesPoint represents a value of time series data on a date.
+	//
+	// Value is kept as string since Fitbit APIs mix numeric formats.
+	TimeSeriesPoint struct {
+		Date  *time.Time
+		Value string
+	}
+)
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (p *TimeSeriesPoint) UnmarshalJSON(b []byte) error {
+	var raw rawTimeSeriesPoint
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return err
+	}
+
+	date, err := parseTime(dateFormat, raw.DateTime)
+	if err != nil {
+		return err
+	}
+	value := string(raw.Value)
+	if len(raw.Value) > 0 && raw.Value[0] == '"' {
+		if err := json.Unmarshal(raw.Value, &value); err != nil {
+			return err
+		}
+	}
+
+	p.Date = date
+	p.Value = value
+	return nil
+}
+
+// Float64 parses Value as float64.
+func (p *TimeSeriesPoint) Float64() (float64, error) {
+	return strconv.ParseFloat(p.Value, 64)
+}
+
+// parseTimeSeries parses time series data held by the specified key.
+func parseTimeSeries(b []byte, key string) ([]TimeSeriesPoint, error) {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return nil, err
+	}
+	points := []TimeSeriesPoint{}
+	if data, ok := raw[key]; ok {
+		if err := json.Unmarshal(data, &points); err != nil {
+			return nil, err
+		}
+	}
+	return points, nil
+}
@@ -3,14 +3,20 @@ package fitbit
 import (
 	"context"
 	"crypto/sha256"
+	"crypto/subtle"
 	"encoding/base64"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io/ioutil"
+	"net/http"
 	"net/url"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 
+	"golang.org/x/net/context/ctxhttp"
 	"golang.org/x/oauth2"
 )
 
@@ -20,14 +26,40 @@ var (
 
 	// CodeVerifierLength represents the length of `code_verifier` generating on authorization process.
 	CodeVerifierLength uint64 = 128
+
+	// CodeVerifierFunc generates `code_verifier` of the specified length on authorization process.
+	//
+	// It can be replaced, e.g. to make the code verifier deterministic in tests.
+	CodeVerifierFunc = randomBytes
 )
 
+// GenerateCodeVerifier generates `code_verifier` of CodeVerifierLength using CodeVerifierFunc.
+func GenerateCodeVerifier() ([]byte, error) {
+	return CodeVerifierFunc(CodeVerifierLength)
+}
+
+// CodeChallenge returns `code_challenge` for the code verifier, using CodeChallengeMethod.
+//
+// See more details https://datatracker.ietf.org/doc/html/rfc7636#section-4.2
+func CodeChallenge(codeVerifier []byte) string {
+	hashedCodeVerifier := sha256.Sum256(codeVerifier)
+	return base64.RawURLEncoding.EncodeToString(hashedCodeVerifier[:])
+}
+
+// ErrStateMismatch is returned when the `state` given to the redirect URI
+// does not match the one issued by GenerateAuthCodeURL.
+var ErrStateMismatch = errors.New("fitbit(oauth2): state mismatch")
+
 // Token represents the OAuth 2.0 Token.
+//
+// Token can be serialized as JSON with the same field names as Fitbit uses,
+// and `expiry` is represented in RFC 3339 format. All the fields are always emitted.
+// A missing `expiry` is treated as the zero time.
 type Token struct {
-	AccessToken  string
-	TokenType    string
-	RefreshToken string
-	Expiry       time.Time
+	AccessToken  string    `json:"access_token"`
+	TokenType    string    `json:"token_type"`
+	RefreshToken string    `json:"refresh_token"`
+	Expiry       time.Time `json:"expiry"`
 }
 
 func (t *Token) asOAuth2Token() *oauth2.Token {
@@ -42,35 +74,182 @@ func (t *Token) asOAuth2Token() *oauth2.Token {
 	}
 }
 
+// DefaultExpiryDelta is the default duration before the expiry
+// at which an access token is considered expired and refreshed.
+const DefaultExpiryDelta = 60 * time.Second
+
+// tokenRefresher is a oauth2.TokenSource which returns the last token while it is valid,
+// and refreshes it using the refresh token otherwise.
 type tokenRefresher struct {
-	ctx       context.Context
-	client    *Client
+	ctx    context.Context
+	client *Client
+
+	mu        sync.Mutex
 	lastToken *Token
 }
 
+// valid reports whether the token is valid and not about to expire,
+// considering the expiry delta of the client.
+func (tkr *tokenRefresher) valid(token *Token) bool {
+	if token == nil || token.AccessToken == "" {
+		return false
+	}
+	if token.Expiry.IsZero() {
+		return true
+	}
+	return time.Now().Add(tkr.client.expiryDelta).Before(token.Expiry)
+}
+
 // Token implements the the oauth2.TokenSource interface.
+//
+// It is safe to call from multiple goroutines.
 func (tkr *tokenRefresher) Token() (*oauth2.Token, error) {
-	token, err := retrieveToken(
-		tkr.ctx,
-		tkr.client.oauth2Config.ClientID,
-		tkr.client.oauth2Config.ClientSecret,
-		tkr.client.oauth2Config.Endpoint.TokenURL,
+	tkr.mu.Lock()
+	defer tkr.mu.Unlock()
+	if tkr.valid(tkr.lastToken) {
+		return tkr.lastToken.asOAuth2Token(), nil
+	}
+	if tkr.lastToken == nil {
+		return nil, ErrNilToken
+	}
+	if tkr.lastToken.RefreshToken == "" {
+		return nil, errors.New("fitbit(oauth2): token expired and refresh token is not set")
+	}
+	token, err := tkr.client.refreshToken(tkr.ctx, tkr.lastToken)
+	if err != nil {
+		return nil, err
+	}
+	tkr.lastToken = token
+	return token.asOAuth2Token(), nil
+}
+
+// RefreshToken refreshes `token` regardless of its expiry and returns the new token.
+//
+// The new token is passed to the function set by SetUpdateTokenFunc and saved to the TokenStore set by SetTokenStore,
+// and *TokenPersistError is returned when either fails.
+// It is useful to refresh tokens in advance, e.g. in a maintenance job.
+// The old refresh token is no longer valid once this succeeds.
+//
+// Web API Reference: https://dev.fitbit.com/build/reference/web-api/authorization/refresh-token/
+func (c *Client) RefreshToken(ctx context.Context, token *Token) (*Token, error) {
+	if token == nil {
+		return nil, ErrNilToken
+	}
+	if token.RefreshToken == "" {
+		return nil, errors.New("fitbit(oauth2): refresh token is not set")
+	}
+	newToken, err := c.refreshToken(ctx, token)
+	if err != nil {
+		if rErr := (*oauth2.RetrieveError)(nil); errors.As(err, &rErr) {
+			if e := c.parseError(rErr.Response, rErr.Body); e != nil {
+				return nil, fmt.Errorf("fitbit(oauth2): cannot refresh token: %w", e)
+			}
+		}
+		if pErr := (*TokenPersistError)(nil); errors.As(err, &pErr) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("fitbit(oauth2): cannot refresh token: %w", err)
+	}
+	return newToken, nil
+}
+
+// refreshCall represents a refresh which is in flight or has been completed.
+type refreshCall struct {
+	done  chan struct{}
+	token *Token
+	err   error
+}
+
+// refreshToken refreshes the token using its refresh token.
+//
+// Fitbit invalidates a refresh token once it is used, so concurrent refreshes
+// with the same refresh token are deduplicated and share the result.
+// Successful results are kept until the new token expires,
+// so that a caller holding the old token obtains the new one instead of failing.
+func (c *Client) refreshToken(ctx context.Context, lastToken *Token) (*Token, error) {
+	key := lastToken.RefreshToken
+	c.refreshMu.Lock()
+	if c.refreshCalls == nil {
+		c.refreshCalls = make(map[string]*refreshCall)
+	}
+	if call, ok := c.refreshCalls[key]; ok {
+		c.refreshMu.Unlock()
+		select {
+		case <-call.done:
+			return call.token, call.err
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	c.purgeRefreshCallsLocked(time.Now())
+	call := &refreshCall{done: make(chan struct{})}
+	c.refreshCalls[key] = call
+	c.refreshMu.Unlock()
+
+	token, err := c.retrieveRefreshedToken(ctx, lastToken)
+	call.token, call.err = token, err
+	if pErr := (*TokenPersistError)(nil); errors.As(err, &pErr) {
+		// The refresh itself has succeeded and the old refresh token is no longer valid,
+		// so the new token is shared with the others while this caller reports the failure.
+		call.token, call.err = pErr.NewToken, nil
+	} else if err != nil {
+		// allow the next caller to try again
+		c.refreshMu.Lock()
+		delete(c.refreshCalls, key)
+		c.refreshMu.Unlock()
+	}
+	close(call.done)
+	return token, err
+}
+
+// purgeRefreshCallsLocked removes completed refreshes whose new token has expired.
+// c.refreshMu must be held.
+func (c *Client) purgeRefreshCallsLocked(now time.Time) {
+	for key, call := range c.refreshCalls {
+		select {
+		case <-call.done:
+			if call.token == nil || (!call.token.Expiry.IsZero() && now.After(call.token.Expiry)) {
+				delete(c.refreshCalls, key)
+			}
+		default:
+		}
+	}
+}
+
+func (c *Client) retrieveRefreshedToken(ctx context.Context, lastToken *Token) (*Token, error) {
+	token, userID, err := retrieveToken(
+		c.contextWithHTTPClient(ctx),
+		c.oauth2Config.ClientID,
+		c.oauth2Config.ClientSecret,
+		c.oauth2Config.Endpoint.TokenURL,
 		url.Values{
 			"grant_type":    {"refresh_token"},
-			"refresh_token": {tkr.lastToken.RefreshToken},
+			"refresh_token": {lastToken.RefreshToken},
 		},
-		tkr.client.applicationType,
+		c.applicationType,
 	)
 	if err != nil {
 		return nil, err
 	}
-	if tkr.client.updateTokenFunc != nil {
-		if err := tkr.client.updateTokenFunc(tkr.lastToken, token); err != nil {
-			return nil, err
+	if c.updateTokenFunc != nil {
+		if err := c.updateTokenFunc(lastToken, token); err != nil {
+			return nil, &TokenPersistError{
+				OldToken: lastToken,
+				NewToken: token,
+				Err:      err,
+			}
 		}
 	}
-	tkr.lastToken = token
-	return token.asOAuth2Token(), err
+	if c.tokenStore != nil && userID != "" {
+		if err := c.tokenStore.Save(userID, token); err != nil {
+			return nil, &TokenPersistError{
+				OldToken: lastToken,
+				NewToken: token,
+				Err:      err,
+			}
+		}
+	}
+	return token, nil
 }
 
 type (
@@ -129,31 +308,130 @@ func (t *TokenState) UnmarshalJSON(b []byte) error {
 	return nil
 }
 
-// AuthCodeURL returns an url to link with user's Fitbit account.
+// Prompt represents the `prompt` parameter of the authorization page.
+type Prompt string
+
+const (
+	PromptNone         Prompt = "none"
+	PromptConsent      Prompt = "consent"
+	PromptLogin        Prompt = "login"
+	PromptLoginConsent Prompt = "login consent"
+)
+
+type (
+	authOptions struct {
+		scope     *Scope
+		prompt    Prompt
+		expiresIn int
+	}
+
+	// AuthOption represents an option of GenerateAuthCodeURL.
+	AuthOption func(*authOptions)
+)
+
+// WithScope requests `scope` instead of the scope given to NewClient.
+func WithScope(scope *Scope) AuthOption {
+	return func(o *authOptions) {
+		o.scope = scope
+	}
+}
+
+// WithPrompt sets `prompt` parameter, which controls whether the login and consent dialogs are displayed.
+//
+// When it is not set, PromptConsent is used in debug mode.
+func WithPrompt(prompt Prompt) AuthOption {
+	return func(o *authOptions) {
+		o.prompt = prompt
+	}
+}
+
+// WithExpiresIn sets `expires_in` parameter, which is the lifetime of the access token in seconds.
+//
+// Fitbit only honors it for Implicit Grant Flow.
+func WithExpiresIn(seconds int) AuthOption {
+	return func(o *authOptions) {
+		o.expiresIn = seconds
+	}
+}
+
+// AuthCodeURL returns an url to link with user's Fitbit account,
+// with `state` and `code_verifier` which are required to complete the link.
+//
+// It panics when secure random bytes cannot be generated.
+//
+// Deprecated: Use GenerateAuthCodeURL, which returns the error instead.
+func (c *Client) AuthCodeURL(redirectURI string, options ...AuthOption) (*url.URL, string, string) {
+	authCodeURL, state, codeVerifier, err := c.GenerateAuthCodeURL(redirectURI, options...)
+	if err != nil {
+		panic(err)
+	}
+	return authCodeURL, state, codeVerifier
+}
+
+// GenerateAuthCodeURL returns an url to link with user's Fitbit account,
+// with `state` and `code_verifier` which are required to complete the link.
+//
+// An error is returned when secure random bytes cannot be generated.
 //
 // Web API Reference: https://dev.fitbit.com/build/reference/web-api/authorization/authorize/
 //
 // Web API Reference: https://dev.fitbit.com/build/reference/web-api/developer-guide/authorization/
-func (c *Client) AuthCodeURL(redirectURI string) (*url.URL, string, string) {
-	state := string(randomBytes(CSRFStateLength))
-	codeVerifier := randomBytes(CodeVerifierLength)
-	hashedCodeVerifier := sha256.Sum256(codeVerifier)
-	codeChallenge := base64.RawURLEncoding.EncodeToString(hashedCodeVerifier[:])
+func (c *Client) GenerateAuthCodeURL(redirectURI string, options ...AuthOption) (*url.URL, string, string, error) {
+	o := &authOptions{}
+	for _, option := range options {
+		option(o)
+	}
+	state, err := randomBytes(CSRFStateLength)
+	if err != nil {
+		return nil, "", "", err
+	}
+	codeVerifier, err := GenerateCodeVerifier()
+	if err != nil {
+		return nil, "", "", err
+	}
 	opts := []oauth2.AuthCodeOption{
-		oauth2.SetAuthURLParam("code_challenge", codeChallenge),
+		oauth2.SetAuthURLParam("code_challenge", CodeChallenge(codeVerifier)),
 		oauth2.SetAuthURLParam("code_challenge_method", CodeChallengeMethod),
 		oauth2.SetAuthURLParam("redirect_uri", redirectURI),
 	}
-	if c.debugMode {
+	if o.prompt != "" {
+		opts = append(opts, oauth2.SetAuthURLParam("prompt", string(o.prompt)))
+	} else if c.debugMode {
 		opts = append(opts, oauth2.ApprovalForce)
 	}
-	urlString := c.oauth2Config.AuthCodeURL(state, opts...)
+	if o.expiresIn > 0 {
+		opts = append(opts, oauth2.SetAuthURLParam("expires_in", strconv.Itoa(o.expiresIn)))
+	}
+	oauth2Config := c.oauth2Config
+	if o.scope != nil {
+		copied := *c.oauth2Config
+		copied.Scopes = o.scope.convert()
+		oauth2Config = &copied
+	}
+	urlString := oauth2Config.AuthCodeURL(string(state), opts...)
 	authCodeURL, _ := url.Parse(urlString) // error should never happen
-	return authCodeURL, state, string(codeVerifier)
+	return authCodeURL, string(state), string(codeVerifier), nil
+}
+
+// MissingScopes returns the names of the scopes in `requested` which the user did not grant,
+// since Fitbit lets the user deselect some of the requested scopes on the consent page.
+// An empty slice is returned when all the requested scopes are granted.
+func (lr *LinkResponse) MissingScopes(requested *Scope) []string {
+	if requested == nil {
+		return []string{}
+	}
+	granted := lr.Scope
+	if granted == nil {
+		granted = &Scope{}
+	}
+	return granted.Missing(requested)
 }
 
 // Link obtains data for the user to interact with Fitbit APIs.
 //
+// When a TokenStore is set, the token is saved to it. If saving fails,
+// the response is returned together with the error so that the token is not lost.
+//
 // Web API Reference: https://dev.fitbit.com/build/reference/web-api/authorization/oauth2-token/
 func (c *Client) Link(ctx context.Context, code, codeVerifier, reqURIString string) (*LinkResponse, error) {
 	opts := []oauth2.AuthCodeOption{
@@ -165,16 +443,16 @@ func (c *Client) Link(ctx context.Context, code, codeVerifier, reqURIString stri
 		// since this is noted "required" in the official document
 		opts = append(opts, oauth2.SetAuthURLParam("client_id", c.oauth2Config.ClientID))
 	}
-	token, err := c.oauth2Config.Exchange(ctx, code, opts...)
+	token, err := c.oauth2Config.Exchange(c.contextWithHTTPClient(ctx), code, opts...)
 	if err != nil {
 		if rErr := (*oauth2.RetrieveError)(nil); errors.As(err, &rErr) {
-			if e := parseError(rErr.Response, rErr.Body); e != nil {
+			if e := c.parseError(rErr.Response, rErr.Body); e != nil {
 				return nil, fmt.Errorf("fitbit(oauth2): cannot fetch token: %w", e)
 			}
 		}
 		return nil, fmt.Errorf("fitbit(oauth2): cannot fetch token: %w", err)
 	}
-	return &LinkResponse{
+	linkResp := &LinkResponse{
 		UserID: token.Extra("user_id").(string),
 		Scope:  newScope(strings.Split(token.Extra("scope").(string), " ")),
 		Token: &Token{
@@ -183,7 +461,43 @@ func (c *Client) Link(ctx context.Context, code, codeVerifier, reqURIString stri
 			RefreshToken: token.RefreshToken,
 			Expiry:       token.Expiry,
 		},
-	}, nil
+	}
+	if c.tokenStore != nil {
+		if err := c.tokenStore.Save(linkResp.UserID, linkResp.Token); err != nil {
+			return linkResp, fmt.Errorf("fitbit: cannot save token: %w", err)
+		}
+	}
+	return linkResp, nil
+}
+
+// ValidateState checks whether `got`, the `state` given to the redirect URI,
+// matches `expected`, the one issued by GenerateAuthCodeURL, in constant time.
+//
+// ErrStateMismatch is returned when they do not match.
+func ValidateState(expected, got string) error {
+	if expected == "" || subtle.ConstantTimeCompare([]byte(expected), []byte(got)) != 1 {
+		return ErrStateMismatch
+	}
+	return nil
+}
+
+// LinkFromCallback extracts `code` and `state` from the request to the redirect URI,
+// validates the state, and calls Link.
+//
+// `redirectURI` must be the same one given to GenerateAuthCodeURL.
+func (c *Client) LinkFromCallback(ctx context.Context, r *http.Request, expectedState, codeVerifier, redirectURI string) (*LinkResponse, error) {
+	query := r.URL.Query()
+	if errCode := query.Get("error"); errCode != "" {
+		return nil, fmt.Errorf("fitbit(oauth2): authorization failed: %s: %s", errCode, query.Get("error_description"))
+	}
+	if err := ValidateState(expectedState, query.Get("state")); err != nil {
+		return nil, err
+	}
+	code := query.Get("code")
+	if code == "" {
+		return nil, errors.New("fitbit(oauth2): code is not given")
+	}
+	return c.Link(ctx, code, codeVerifier, redirectURI)
 }
 
 // IntrospectToken retrieves the active state of an OAuth 2.0 token.
@@ -204,12 +518,32 @@ func (c *Client) IntrospectToken(ctx context.Context, token *Token) (*TokenState
 	return &tokenState, rateLimit, b, nil
 }
 
+// RevokeToken disables the user's authorizations and all tokens,
+// associated with the specified token.
+//
+// The access token is used to revoke if it is set, otherwise the refresh token is used.
+// The token is never refreshed, and the client is authenticated in the same way as the token endpoint.
+//
+// Web API Reference: https://dev.fitbit.com/build/reference/web-api/authorization/revoke-token/
+func (c *Client) RevokeToken(ctx context.Context, token *Token) (*RateLimit, error) {
+	if token == nil {
+		return nil, ErrNilToken
+	}
+	if token.AccessToken != "" {
+		return c.revokeToken(ctx, token.AccessToken)
+	}
+	return c.revokeToken(ctx, token.RefreshToken)
+}
+
 // RevokeAccessToken disables the user's authorizations and all tokens,
 // associated with the specified access token.
 //
 // Web API Reference: https://dev.fitbit.com/build/reference/web-api/authorization/revoke-token/
 func (c *Client) RevokeAccessToken(ctx context.Context, token *Token) (*RateLimit, error) {
-	return c.revokeToken(ctx, token, token.AccessToken)
+	if token == nil {
+		return nil, ErrNilToken
+	}
+	return c.revokeToken(ctx, token.AccessToken)
 }
 
 // RevokeRefreshToken disables the user's authorizations and all tokens,
@@ -217,19 +551,42 @@ func (c *Client) RevokeAccessToken(ctx context.Context, token *Token) (*RateLimi
 //
 // Web API Reference: https://dev.fitbit.com/build/reference/web-api/authorization/revoke-token/
 func (c *Client) RevokeRefreshToken(ctx context.Context, token *Token) (*RateLimit, error) {
-	return c.revokeToken(ctx, token, token.RefreshToken)
+	if token == nil {
+		return nil, ErrNilToken
+	}
+	return c.revokeToken(ctx, token.RefreshToken)
 }
 
-func (c *Client) revokeToken(ctx context.Context, token *Token, target string) (*RateLimit, error) {
-	endpoint := c.getEndpoint("RevokeToken")
-	values := url.Values{}
-	values.Set("token", target)
-	if c.applicationType != ServerApplication {
-		values.Set("client_id", c.oauth2Config.ClientID)
+// revokeToken revokes `target` authenticating the client as the token endpoint does,
+// so that the token is not refreshed just before it is revoked.
+func (c *Client) revokeToken(ctx context.Context, target string) (*RateLimit, error) {
+	if target == "" {
+		return nil, errors.New("fitbit(oauth2): no token to revoke")
 	}
-	_, rateLimit, err := c.postRequest(ctx, token, endpoint, values)
+	endpoint := c.getEndpoint("RevokeToken")
+	req, err := newTokenRequest(endpoint.url, c.oauth2Config.ClientID, c.oauth2Config.ClientSecret, url.Values{"token": {target}}, c.applicationType)
 	if err != nil {
 		return nil, err
 	}
+	if _, ok := ctx.Deadline(); !ok && c.defaultTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.defaultTimeout)
+		defer cancel()
+	}
+	ctx = c.contextWithHTTPClient(ctx)
+	resp, err := ctxhttp.Do(ctx, contextClient(ctx), req)
+	if err != nil {
+		return nil, wrapAsRequestError("Post", endpoint.url, err)
+	}
+	defer resp.Body.Close()
+	b, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, wrapAsRequestError("Post", endpoint.url, err)
+	}
+	rateLimit := extractRateLimit(&resp.Header)
+	c.setLastRateLimit(rateLimit)
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, wrapAsRequestError("Post", endpoint.url, c.parseError(resp, b))
+	}
 	return rateLimit, nil
 }
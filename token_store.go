@@ -0,0 +1,73 @@
+package fitbit
+
+import (
+	"errors"
+	"sync"
+)
+
+// ErrTokenNotFound is returned by TokenStore.Load when no token is stored for the user.
+var ErrTokenNotFound = errors.New("fitbit: token not found")
+
+// TokenStore is the interface to persist tokens by user.
+//
+// When it is set by `SetTokenStore`, the token obtained by Link and the refreshed tokens are saved to it,
+// and `LoadToken` loads tokens from it.
+// Implementations must be safe for concurrent use.
+type TokenStore interface {
+	// Load returns the token of the user, or ErrTokenNotFound when it is not stored.
+	Load(userID string) (*Token, error)
+	// Save stores the token of the user, replacing the existing one.
+	Save(userID string, token *Token) error
+}
+
+// SetTokenStore sets the store to persist tokens by user.
+//
+// It works alongside the function set by `SetUpdateTokenFunc`, which is invoked before the token is saved.
+// When saving a refreshed token fails, the request fails with *TokenPersistError.
+// It is not set by default.
+func (c *Client) SetTokenStore(store TokenStore) {
+	c.tokenStore = store
+}
+
+// LoadToken loads the token of the user from the store set by `SetTokenStore`.
+func (c *Client) LoadToken(userID string) (*Token, error) {
+	if c.tokenStore == nil {
+		return nil, errors.New("fitbit: token store is not set")
+	}
+	return c.tokenStore.Load(userID)
+}
+
+// MemoryTokenStore is a TokenStore which keeps tokens in memory.
+//
+// The zero value is ready to use.
+type MemoryTokenStore struct {
+	mu     sync.RWMutex
+	tokens map[string]*Token
+}
+
+// Load implements the TokenStore interface.
+func (s *MemoryTokenStore) Load(userID string) (*Token, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	token, ok := s.tokens[userID]
+	if !ok {
+		return nil, ErrTokenNotFound
+	}
+	copied := *token
+	return &copied, nil
+}
+
+// Save implements the TokenStore interface.
+func (s *MemoryTokenStore) Save(userID string, token *Token) error {
+	if token == nil {
+		return ErrNilToken
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.tokens == nil {
+		s.tokens = make(map[string]*Token)
+	}
+	copied := *token
+	s.tokens[userID] = &copied
+	return nil
+}
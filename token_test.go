@@ -0,0 +1,276 @@
+package fitbit
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+const testTokenResponse = `{"access_token":"new-access-token","expires_in":28800,"refresh_token":"new-refresh-token","scope":"profile","token_type":"Bearer","user_id":"ABC123"}`
+
+// newTestClient returns a client whose API and token endpoint are served by `handler`.
+func newTestClient(t *testing.T, handler http.Handler) *Client {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	c := NewClient("client-id", "client-secret", ServerApplication, nil)
+	if err := c.SetAPIBaseURL(server.URL); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.SetOAuth2Endpoint(server.URL+"/oauth2/authorize", server.URL+"/oauth2/token"); err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+// expiredTokenServer serves the token endpoint counting refresh requests, and responds to the other requests with `{}`.
+func expiredTokenServer(refreshes *int32) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/oauth2/token" {
+			atomic.AddInt32(refreshes, 1)
+			// widen the window in which the other goroutines wait for the refresh
+			time.Sleep(50 * time.Millisecond)
+			w.Write([]byte(testTokenResponse))
+			return
+		}
+		if r.Header.Get("Authorization") != "Bearer new-access-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"errors":[{"errorType":"expired_token","message":"Access token expired"}],"success":false}`))
+			return
+		}
+		w.Write([]byte(`{}`))
+	})
+}
+
+func newExpiredToken() *Token {
+	return &Token{
+		AccessToken:  "old-access-token",
+		TokenType:    "Bearer",
+		RefreshToken: "old-refresh-token",
+		Expiry:       time.Now().Add(-time.Hour),
+	}
+}
+
+func TestRefreshTokenConcurrently(t *testing.T) {
+	var refreshes int32
+	c := newTestClient(t, expiredTokenServer(&refreshes))
+	token := newExpiredToken()
+
+	const n = 10
+	var wg sync.WaitGroup
+	errs := make([]error, n)
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			_, _, errs[i] = c.getRequest(context.Background(), token, c.getEndpoint("GetProfile", CurrentUserID))
+		}(i)
+	}
+	wg.Wait()
+
+	for i, err := range errs {
+		if err != nil {
+			t.Errorf("request %d: %v", i, err)
+		}
+	}
+	if got := atomic.LoadInt32(&refreshes); got != 1 {
+		t.Errorf("refresh requests = %d, want 1", got)
+	}
+}
+
+func TestRefreshTokenPersistError(t *testing.T) {
+	var refreshes int32
+	c := newTestClient(t, expiredTokenServer(&refreshes))
+	persistErr := errors.New("cannot save")
+	c.SetUpdateTokenFunc(func(*Token, *Token) error {
+		return persistErr
+	})
+	token := newExpiredToken()
+
+	_, err := c.RefreshToken(context.Background(), token)
+	var pErr *TokenPersistError
+	if !errors.As(err, &pErr) || !errors.Is(err, persistErr) {
+		t.Fatalf("RefreshToken() error = %v, want *TokenPersistError", err)
+	}
+	if pErr.NewToken == nil || pErr.NewToken.RefreshToken != "new-refresh-token" {
+		t.Fatalf("NewToken = %+v, want the refreshed token", pErr.NewToken)
+	}
+
+	// The old refresh token has been rotated, so the next caller must receive the new token without refreshing again.
+	newToken, err := c.RefreshToken(context.Background(), token)
+	if err != nil {
+		t.Fatalf("RefreshToken() error = %v", err)
+	}
+	if newToken.AccessToken != "new-access-token" {
+		t.Errorf("AccessToken = %q, want %q", newToken.AccessToken, "new-access-token")
+	}
+	if got := atomic.LoadInt32(&refreshes); got != 1 {
+		t.Errorf("refresh requests = %d, want 1", got)
+	}
+}
+
+// revokeServer serves the revoke endpoint recording the revoked tokens and the Authorization headers,
+// and counts requests to the token endpoint.
+func revokeServer(refreshes *int32, revoked, authorizations *[]string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/oauth2/token":
+			atomic.AddInt32(refreshes, 1)
+			w.Write([]byte(testTokenResponse))
+		case "/oauth2/revoke":
+			if err := r.ParseForm(); err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			*revoked = append(*revoked, r.PostForm.Get("token")+" "+r.PostForm.Get("client_id"))
+			*authorizations = append(*authorizations, r.Header.Get("Authorization"))
+			w.Write([]byte(`{}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+}
+
+func TestRevokeToken(t *testing.T) {
+	basic := "Basic " + base64.StdEncoding.EncodeToString([]byte("client-id:client-secret"))
+	tests := []struct {
+		name              string
+		token             *Token
+		wantRevoked       string
+		wantAuthorization string
+	}{
+		{
+			name:              "expired access token",
+			token:             newExpiredToken(),
+			wantRevoked:       "old-access-token ",
+			wantAuthorization: basic,
+		},
+		{
+			name:              "refresh token only",
+			token:             &Token{RefreshToken: "old-refresh-token"},
+			wantRevoked:       "old-refresh-token ",
+			wantAuthorization: basic,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				refreshes      int32
+				revoked        []string
+				authorizations []string
+			)
+			c := newTestClient(t, revokeServer(&refreshes, &revoked, &authorizations))
+			c.SetUpdateTokenFunc(func(*Token, *Token) error {
+				t.Error("the token must not be updated on revocation")
+				return nil
+			})
+			if _, err := c.RevokeToken(context.Background(), tt.token); err != nil {
+				t.Fatalf("RevokeToken() error = %v", err)
+			}
+			if len(revoked) != 1 || revoked[0] != tt.wantRevoked {
+				t.Errorf("revoked = %q, want [%q]", revoked, tt.wantRevoked)
+			}
+			if len(authorizations) != 1 || authorizations[0] != tt.wantAuthorization {
+				t.Errorf("Authorization = %q, want [%q]", authorizations, tt.wantAuthorization)
+			}
+			if got := atomic.LoadInt32(&refreshes); got != 0 {
+				t.Errorf("refresh requests = %d, want 0", got)
+			}
+		})
+	}
+}
+
+func TestRevokeTokenClientApplication(t *testing.T) {
+	var (
+		refreshes      int32
+		revoked        []string
+		authorizations []string
+	)
+	server := httptest.NewServer(revokeServer(&refreshes, &revoked, &authorizations))
+	t.Cleanup(server.Close)
+	c := NewClient("client-id", "", ClientApplication, nil)
+	if err := c.SetAPIBaseURL(server.URL); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := c.RevokeToken(context.Background(), newExpiredToken()); err != nil {
+		t.Fatalf("RevokeToken() error = %v", err)
+	}
+	if want := "old-access-token client-id"; len(revoked) != 1 || revoked[0] != want {
+		t.Errorf("revoked = %q, want [%q]", revoked, want)
+	}
+	if len(authorizations) != 1 || authorizations[0] != "" {
+		t.Errorf("Authorization = %q, want no header", authorizations)
+	}
+	if got := atomic.LoadInt32(&refreshes); got != 0 {
+		t.Errorf("refresh requests = %d, want 0", got)
+	}
+}
+
+func TestRevokeTokenNil(t *testing.T) {
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request to %s", r.URL.Path)
+	}))
+	if _, err := c.RevokeToken(context.Background(), nil); !errors.Is(err, ErrNilToken) {
+		t.Errorf("RevokeToken() error = %v, want ErrNilToken", err)
+	}
+}
+
+func TestRevokeTokenError(t *testing.T) {
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusUnauthorized)
+		w.Write([]byte(`{"errors":[{"errorType":"invalid_client","message":"Invalid authorization header format."}],"success":false}`))
+	}))
+	_, err := c.RevokeToken(context.Background(), newExpiredToken())
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusUnauthorized {
+		t.Errorf("RevokeToken() error = %v, want *APIError of 401", err)
+	}
+}
+
+func TestTokenJSON(t *testing.T) {
+	token := Token{
+		AccessToken:  "access-token",
+		TokenType:    "Bearer",
+		RefreshToken: "refresh-token",
+		Expiry:       time.Date(2022, 1, 2, 3, 4, 5, 0, time.UTC),
+	}
+	b, err := json.Marshal(token)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	const want = `{"access_token":"access-token","token_type":"Bearer","refresh_token":"refresh-token","expiry":"2022-01-02T03:04:05Z"}`
+	if string(b) != want {
+		t.Errorf("json.Marshal() = %s, want %s", b, want)
+	}
+	var decoded Token
+	if err := json.Unmarshal(b, &decoded); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if decoded.AccessToken != token.AccessToken || decoded.TokenType != token.TokenType ||
+		decoded.RefreshToken != token.RefreshToken || !decoded.Expiry.Equal(token.Expiry) {
+		t.Errorf("round trip = %+v, want %+v", decoded, token)
+	}
+}
+
+func TestTokenUnmarshalJSONMissingFields(t *testing.T) {
+	for _, body := range []string{`{"access_token":"access-token","token_type":"Bearer","refresh_token":"refresh-token"}`, `{}`} {
+		var token Token
+		if err := json.Unmarshal([]byte(body), &token); err != nil {
+			t.Fatalf("json.Unmarshal(%s) error = %v", body, err)
+		}
+		if !token.Expiry.IsZero() {
+			t.Errorf("json.Unmarshal(%s): Expiry = %v, want the zero time", body, token.Expiry)
+		}
+	}
+}
@@ -0,0 +1,41 @@
+package fitbit
+
+import "context"
+
+// Attribute keys set to spans started by Tracer.
+const (
+	AttributeHTTPMethod         = "http.request.method"         // AttributeHTTPMethod is the key of the HTTP method
+	AttributeURLPath            = "url.path"                    // AttributeURLPath is the key of the path of the endpoint
+	AttributeHTTPStatusCode     = "http.response.status_code"   // AttributeHTTPStatusCode is the key of the status code, which is not set when no response is received
+	AttributeRateLimitRemaining = "fitbit.rate_limit.remaining" // AttributeRateLimitRemaining is the key of the remaining rate limit, which is not set when it is unknown
+)
+
+type (
+	// Tracer starts a span for each request to Fitbit APIs.
+	//
+	// It is intended to be implemented by adapters of tracing libraries like OpenTelemetry,
+	// so that this package does not depend on them.
+	Tracer interface {
+		// Start starts a span named `name`, and returns the context holding it and the span.
+		// The returned context is used for the request, including token refresh and retries.
+		Start(ctx context.Context, name string) (context.Context, Span)
+	}
+
+	// Span represents a span started by Tracer.
+	Span interface {
+		// SetAttribute sets an attribute of the span. `value` is either string, int or int64.
+		SetAttribute(key string, value interface{})
+		// End ends the span. `err` is the error of the request, or nil on success.
+		End(err error)
+	}
+)
+
+// SetTracer sets the tracer to start a span for each request, which is named like "fitbit GET /1/user/{user-id}/profile.json".
+//
+// The span name has the endpoint templated in the same way as SetMetricsFunc to keep its cardinality bounded,
+// while AttributeURLPath has the actual path. The spans have the attributes of AttributeHTTPMethod, AttributeURLPath,
+// AttributeHTTPStatusCode and AttributeRateLimitRemaining.
+// It is not set by default.
+func (c *Client) SetTracer(tracer Tracer) {
+	c.tracer = tracer
+}
@@ -0,0 +1,59 @@
+package fitbit
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+)
+
+type recordingTracer struct {
+	spans []*recordingSpan
+}
+
+func (t *recordingTracer) Start(ctx context.Context, name string) (context.Context, Span) {
+	span := &recordingSpan{name: name, attributes: make(map[string]interface{})}
+	t.spans = append(t.spans, span)
+	return ctx, span
+}
+
+type recordingSpan struct {
+	name       string
+	attributes map[string]interface{}
+	ended      bool
+}
+
+func (s *recordingSpan) SetAttribute(key string, value interface{}) {
+	s.attributes[key] = value
+}
+
+func (s *recordingSpan) End(err error) {
+	s.ended = true
+}
+
+func TestTracerSpanName(t *testing.T) {
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{}`))
+	}))
+	tracer := &recordingTracer{}
+	c.SetTracer(tracer)
+	token := &Token{AccessToken: "access-token", TokenType: "Bearer", Expiry: time.Now().Add(time.Hour)}
+
+	if _, _, err := c.getRequest(context.Background(), token, c.getEndpoint("GetProfile", "ABC123")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(tracer.spans) != 1 {
+		t.Fatalf("len(spans) = %d, want 1", len(tracer.spans))
+	}
+	span := tracer.spans[0]
+	if want := "fitbit GET /1/user/{user-id}/profile.json"; span.name != want {
+		t.Errorf("name = %q, want %q", span.name, want)
+	}
+	if got, want := span.attributes[AttributeURLPath], "/1/user/ABC123/profile.json"; got != want {
+		t.Errorf("%s = %v, want %q", AttributeURLPath, got, want)
+	}
+	if !span.ended {
+		t.Error("span is not ended")
+	}
+}
@@ -63,6 +63,10 @@ type (
 		ShareText               string
 	}
 
+	rawBadgesResponse struct {
+		Badges []Badge `json:"badges"`
+	}
+
 	// Features represents user's features.
 	Features struct {
 		ExerciseGoal bool `json:"exerciseGoal"`
@@ -127,6 +131,20 @@ type (
 		} `json:"user"`
 	}
 
+	// ProfileUpdate represents parameters to update user's profile.
+	//
+	// Only non-nil fields are sent.
+	ProfileUpdate struct {
+		Gender              *string    // MALE, FEMALE or NA
+		Birthday            *time.Time // only the date part is used
+		Height              *float64   // in the unit of the language setting
+		StrideLengthWalking *float64   // in the unit of the language setting
+		StrideLengthRunning *float64   // in the unit of the language setting
+		Timezone            *string    // e.g. "America/Los_Angeles"
+		Locale              *Locale
+		FoodsLocale         *Locale
+	}
+
 	// Profile represents user's profile.
 	Profile struct {
 		EncodedID                string
@@ -344,9 +362,11 @@ func (p *Profile) UnmarshalJSON(b []byte) error {
 //
 // Scope.Location and Scope.Nutrition is required to obtain some fields.
 //
+// The units of some fields follow the language set by `SetLanguage`.
+//
 // Web API Reference: https://dev.fitbit.com/build/reference/web-api/user/get-profile/
 func (c *Client) GetProfile(ctx context.Context, userID string, token *Token) (*Profile, *RateLimit, []byte, error) {
-	endpoint := c.getEndpoint("GetProfile", userID)
+	endpoint := c.getEndpoint("GetProfile", resolveUserID(userID))
 	b, rateLimit, err := c.getRequest(ctx, token, endpoint)
 	if err != nil {
 		return nil, nil, b, err
@@ -357,3 +377,75 @@ func (c *Client) GetProfile(ctx context.Context, userID string, token *Token) (*
 	}
 	return &profile, rateLimit, b, nil
 }
+
+func (pu *ProfileUpdate) values() url.Values {
+	values := url.Values{}
+	if pu.Gender != nil {
+		values.Set("gender", *pu.Gender)
+	}
+	if pu.Birthday != nil {
+		values.Set("birthday", pu.Birthday.Format(dateFormat))
+	}
+	if pu.Height != nil {
+		values.Set("height", formatFloat(*pu.Height))
+	}
+	if pu.StrideLengthWalking != nil {
+		values.Set("strideLengthWalking", formatFloat(*pu.StrideLengthWalking))
+	}
+	if pu.StrideLengthRunning != nil {
+		values.Set("strideLengthRunning", formatFloat(*pu.StrideLengthRunning))
+	}
+	if pu.Timezone != nil {
+		values.Set("timezone", *pu.Timezone)
+	}
+	if pu.Locale != nil {
+		values.Set("locale", pu.Locale.asString())
+	}
+	if pu.FoodsLocale != nil {
+		values.Set("foodsLocale", pu.FoodsLocale.asString())
+	}
+	return values
+}
+
+// UpdateProfile updates the user's profile data, and returns the updated profile.
+//
+// Scope.Profile is required.
+//
+// Web API Reference: https://dev.fitbit.com/build/reference/web-api/user/update-profile/
+func (c *Client) UpdateProfile(ctx context.Context, userID string, params ProfileUpdate, token *Token) (*Profile, *RateLimit, []byte, error) {
+	endpoint := c.getEndpoint("UpdateProfile", resolveUserID(userID))
+	b, rateLimit, err := c.postRequest(ctx, token, endpoint, params.values())
+	if err != nil {
+		return nil, nil, b, err
+	}
+	var profile Profile
+	if err := json.Unmarshal(b, &profile); err != nil {
+		return nil, rateLimit, b, err
+	}
+	return &profile, rateLimit, b, nil
+}
+
+// GetBadges retrieves a list of the user's badges.
+//
+// An empty slice is returned when the user has no badges.
+//
+// Scope.Profile is required.
+//
+// The descriptions of badges follow the language set by `SetLanguage`.
+//
+// Web API Reference: https://dev.fitbit.com/build/reference/web-api/user/get-badges/
+func (c *Client) GetBadges(ctx context.Context, userID string, token *Token) ([]Badge, *RateLimit, []byte, error) {
+	endpoint := c.getEndpoint("GetBadges", resolveUserID(userID))
+	b, rateLimit, err := c.getRequest(ctx, token, endpoint)
+	if err != nil {
+		return nil, nil, b, err
+	}
+	var resp rawBadgesResponse
+	if err := json.Unmarshal(b, &resp); err != nil {
+		return nil, rateLimit, b, err
+	}
+	if resp.Badges == nil {
+		resp.Badges = []Badge{}
+	}
+	return resp.Badges, rateLimit, b, nil
+}
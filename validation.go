@@ -0,0 +1,76 @@
+package fitbit
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrInvalidDateRange is returned when a date range is invalid,
+// e.g. the end date is before the start date or the range exceeds the maximum of the endpoint.
+//
+// The returned errors wrap it, so use errors.Is to detect it.
+var ErrInvalidDateRange = errors.New("fitbit: invalid date range")
+
+func validateLogID(logID int64) error {
+	if logID <= 0 {
+		return fmt.Errorf("fitbit: invalid log ID %d", logID)
+	}
+	return nil
+}
+
+func validateActivityID(activityID int64) error {
+	if activityID <= 0 {
+		return fmt.Errorf("fitbit: invalid activity ID %d", activityID)
+	}
+	return nil
+}
+
+func validateFoodID(foodID int64) error {
+	if foodID <= 0 {
+		return fmt.Errorf("fitbit: invalid food ID %d", foodID)
+	}
+	return nil
+}
+
+func validateMealID(mealID int64) error {
+	if mealID <= 0 {
+		return fmt.Errorf("fitbit: invalid meal ID %d", mealID)
+	}
+	return nil
+}
+
+func validateAlarmID(alarmID int64) error {
+	if alarmID <= 0 {
+		return fmt.Errorf("fitbit: invalid alarm ID %d", alarmID)
+	}
+	return nil
+}
+
+// validateDateRange checks that `start` is not after `end`,
+// the range does not exceed `maxDays` days, and neither date is in the future.
+//
+// The dates are compared with today in the location of each date,
+// allowing one day ahead since the user's timezone may be ahead of it.
+// `maxDays` of 0 means no limit.
+func validateDateRange(start, end time.Time, maxDays int) error {
+	days := daysBetween(start, end) + 1
+	if days < 1 {
+		return fmt.Errorf("%w: end date %s is before start date %s", ErrInvalidDateRange, end.Format(dateFormat), start.Format(dateFormat))
+	}
+	if maxDays > 0 && days > maxDays {
+		return fmt.Errorf("%w: date range of %d days exceeds the maximum of %d days", ErrInvalidDateRange, days, maxDays)
+	}
+	for _, date := range []time.Time{start, end} {
+		if isFutureDate(date) {
+			return fmt.Errorf("%w: date %s is in the future", ErrInvalidDateRange, date.Format(dateFormat))
+		}
+	}
+	return nil
+}
+
+// isFutureDate reports whether the date of `date` is after today in its location,
+// allowing one day ahead since the user's timezone may be ahead of it.
+func isFutureDate(date time.Time) bool {
+	return daysBetween(time.Now().In(date.Location()), date) > 1
+}